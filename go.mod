@@ -2,10 +2,7 @@ module github.com/retr0h/git-url-parse
 
 go 1.22
 
-require (
-	github.com/chainguard-dev/git-urls v1.0.2
-	github.com/stretchr/testify v1.10.0
-)
+require github.com/stretchr/testify v1.10.0
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
@@ -21,11 +21,8 @@
 package internal
 
 import (
-	"github.com/retr0h/git-url-parse/pkg/api"
+	"github.com/retr0h/git-url-parse/pkg"
 )
 
 // ParserManager manager responsible for each Repository parsing operations.
-type ParserManager interface {
-	ShouldParse(host string) bool
-	Parse(url string) (*api.Repository, error)
-}
+type ParserManager = pkg.ParserManager
@@ -0,0 +1,98 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package azure_test
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/retr0h/git-url-parse/internal"
+	"github.com/retr0h/git-url-parse/internal/repositories/azure"
+)
+
+type AzurePublicTestSuite struct {
+	suite.Suite
+
+	rm internal.ParserManager
+
+	logger *slog.Logger
+}
+
+func (suite *AzurePublicTestSuite) SetupTest() {
+	suite.logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	suite.rm = azure.New(suite.logger)
+}
+
+func (suite *AzurePublicTestSuite) TestShouldParse() {
+	type test struct {
+		input string
+		want  bool
+	}
+
+	tests := []test{
+		{
+			input: "dev.azure.com",
+			want:  true,
+		},
+		{
+			input: "ssh.dev.azure.com",
+			want:  true,
+		},
+		{
+			input: "org.visualstudio.com",
+			want:  true,
+		},
+		// failure cases
+		{
+			input: "example.com",
+			want:  false,
+		},
+		{
+			input: "example",
+			want:  false,
+		},
+		{
+			input: ".com",
+			want:  false,
+		},
+		{
+			input: "azure.com",
+			want:  false,
+		},
+	}
+
+	for _, tc := range tests {
+		got := suite.rm.ShouldParse(tc.input)
+
+		assert.Equal(suite.T(), tc.want, got)
+	}
+}
+
+// In order for `go test` to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run.
+func TestAzurePublicTestSuite(t *testing.T) {
+	suite.Run(t, new(AzurePublicTestSuite))
+}
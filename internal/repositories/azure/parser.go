@@ -0,0 +1,172 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package azure
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/retr0h/git-url-parse/internal/repositories"
+	"github.com/retr0h/git-url-parse/pkg/api"
+	"github.com/retr0h/git-url-parse/pkg/giturl"
+)
+
+const (
+	providerName string = "azure"
+	// sshHosts the SSH hosts, including the legacy host of
+	// `visualstudio.com` organizations.
+	sshHosts string = `^(?:ssh\.dev\.azure\.com|vs-ssh\.visualstudio\.com)$`
+)
+
+var patterns = repositories.MustCompile([]repositories.Pattern{
+	{
+		Schemes: []string{"https"},
+		Host:    `^dev\.azure\.com$`,
+		Path:    `^/(?P<organization>[^/]+)/(?P<project>[^/]+)/_git/(?P<repo>[^/]+)$`,
+	},
+	{
+		Schemes: []string{"https"},
+		Host:    `^(?P<organization>[^.]+)\.visualstudio\.com$`,
+		Path:    `^/(?:DefaultCollection/)?(?P<project>[^/]+)/_git/(?P<repo>[^/]+)$`,
+	},
+	{
+		Schemes: []string{"ssh", "git+ssh"},
+		Host:    sshHosts,
+		Path:    `^/v3/(?P<organization>[^/]+)/(?P<project>[^/]+)/(?P<repo>[^/]+)$`,
+	},
+	{
+		Schemes: []string{repositories.SchemeSCPLike},
+		Host:    sshHosts,
+		Path:    `^v3/(?P<organization>[^/]+)/(?P<project>[^/]+)/(?P<repo>[^/]+)$`,
+	},
+})
+
+// Parse the provided Azure DevOps URL.
+func (a *Azure) Parse(url string) (*api.Repository, error) {
+	return repositories.Parse(a, url)
+}
+
+// ParseURL parse the tokens of the provided, redacted, Azure DevOps URL.
+func (a *Azure) ParseURL(url string, u *giturl.URL) (*api.Repository, error) {
+	for _, pattern := range patterns {
+		mm := pattern.Match(u)
+
+		a.logger.LogAttrs(
+			context.Background(),
+			slog.LevelDebug,
+			"matching url",
+			slog.String("url", url),
+			slog.String("regexp", pattern.Path),
+		)
+
+		if mm != nil {
+			ref, path := parseQuery(u.Query)
+			lineStart, lineEnd := parseLines(u.Query)
+
+			return &api.Repository{
+				Protocol:     repositories.Protocol(u),
+				Host:         u.Host,
+				Provider:     providerName,
+				Resource:     u.Host,
+				Owner:        mm["organization"],
+				Organization: mm["organization"],
+				Project:      mm["project"],
+				Repo:         mm["repo"],
+				Path:         path,
+				Branch:       repositories.BranchName(ref),
+				Ref:          ref,
+				LineStart:    lineStart,
+				LineEnd:      lineEnd,
+				User:         u.User,
+				Port:         u.Port,
+				Pathname:     u.Path,
+				HREF:         url,
+			}, nil
+		}
+	}
+
+	return nil, repositories.NoMatch(url, u, providerName, patterns)
+}
+
+// versions the kind of reference named by each version prefix.
+var versions = map[string]api.RefKind{
+	"GB": api.RefKindBranch,
+	"GT": api.RefKindTag,
+	"GC": api.RefKindCommit,
+}
+
+// parseQuery return the typed reference and path from a browse URL's query
+// string, as in `?path=/README.md&version=GBmain`.  The version is prefixed
+// with `GB` for a branch, `GT` for a tag, and `GC` for a commit.
+func parseQuery(query string) (api.Ref, string) {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return api.Ref{}, ""
+	}
+
+	path := strings.TrimPrefix(values.Get("path"), "/")
+
+	version := values.Get("version")
+	if len(version) > 2 {
+		if kind, ok := versions[version[:2]]; ok {
+			return api.Ref{Kind: kind, Name: version[2:]}, path
+		}
+	}
+
+	if version == "" {
+		return api.Ref{}, path
+	}
+
+	return repositories.ClassifyRef(version), path
+}
+
+// parseLines return the first and last lines selected by a browse URL's
+// query string, as in `?line=10&lineEnd=21&lineStartColumn=1&lineEndColumn=1`.
+// A selection ending on the first column of a line excludes that line, and
+// reversed selections are swapped.
+func parseLines(query string) (int, int) {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return 0, 0
+	}
+
+	start, err := strconv.Atoi(values.Get("line"))
+	if err != nil || start < 1 {
+		return 0, 0
+	}
+
+	end, err := strconv.Atoi(values.Get("lineEnd"))
+	if err != nil || end < 1 {
+		return start, start
+	}
+	if end < start {
+		return end, start
+	}
+
+	if values.Get("lineEndColumn") == "1" && end > start {
+		end--
+	}
+
+	return start, end
+}
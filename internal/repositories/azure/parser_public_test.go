@@ -0,0 +1,316 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package azure_test
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/retr0h/git-url-parse/internal"
+	"github.com/retr0h/git-url-parse/internal/repositories/azure"
+	"github.com/retr0h/git-url-parse/pkg"
+)
+
+type ParserPublicTestSuite struct {
+	suite.Suite
+
+	rm internal.ParserManager
+
+	logger *slog.Logger
+}
+
+func (suite *ParserPublicTestSuite) SetupTest() {
+	suite.logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	suite.rm = azure.New(suite.logger)
+}
+
+func (suite *ParserPublicTestSuite) TestParse() {
+	type repository struct {
+		branch       string
+		href         string
+		organization string
+		owner        string
+		path         string
+		project      string
+		protocol     string
+		protocols    []string
+		provider     string
+		repo         string
+		resource     string
+	}
+
+	type test struct {
+		input   string
+		want    *repository
+		wantErr bool
+	}
+
+	tests := []test{
+		{
+			input: "https://dev.azure.com/organization/project/_git/repository",
+			want: &repository{
+				protocol:     "https",
+				protocols:    []string{"https"},
+				resource:     "dev.azure.com",
+				organization: "organization",
+				project:      "project",
+				owner:        "organization",
+				repo:         "repository",
+				path:         "",
+				branch:       "",
+				provider:     "azure",
+				href:         "https://dev.azure.com/organization/project/_git/repository",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://organization@dev.azure.com/organization/project/_git/repository",
+			want: &repository{
+				protocol:     "https",
+				protocols:    []string{"https"},
+				resource:     "dev.azure.com",
+				organization: "organization",
+				project:      "project",
+				owner:        "organization",
+				repo:         "repository",
+				path:         "",
+				branch:       "",
+				provider:     "azure",
+				href:         "https://organization@dev.azure.com/organization/project/_git/repository",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://dev.azure.com/organization/project/_git/repository?path=/src/main.go&version=GBdev",
+			want: &repository{
+				protocol:     "https",
+				protocols:    []string{"https"},
+				resource:     "dev.azure.com",
+				organization: "organization",
+				project:      "project",
+				owner:        "organization",
+				repo:         "repository",
+				path:         "src/main.go",
+				branch:       "dev",
+				provider:     "azure",
+				href:         "https://dev.azure.com/organization/project/_git/repository?path=/src/main.go&version=GBdev",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://dev.azure.com/organization/project/_git/repository?version=GBfeature%2Flogin",
+			want: &repository{
+				protocol:     "https",
+				protocols:    []string{"https"},
+				resource:     "dev.azure.com",
+				organization: "organization",
+				project:      "project",
+				owner:        "organization",
+				repo:         "repository",
+				path:         "",
+				branch:       "feature/login",
+				provider:     "azure",
+				href:         "https://dev.azure.com/organization/project/_git/repository?version=GBfeature%2Flogin",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://organization.visualstudio.com/project/_git/repository",
+			want: &repository{
+				protocol:     "https",
+				protocols:    []string{"https"},
+				resource:     "organization.visualstudio.com",
+				organization: "organization",
+				project:      "project",
+				owner:        "organization",
+				repo:         "repository",
+				path:         "",
+				branch:       "",
+				provider:     "azure",
+				href:         "https://organization.visualstudio.com/project/_git/repository",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://organization.visualstudio.com/DefaultCollection/project/_git/repository",
+			want: &repository{
+				protocol:     "https",
+				protocols:    []string{"https"},
+				resource:     "organization.visualstudio.com",
+				organization: "organization",
+				project:      "project",
+				owner:        "organization",
+				repo:         "repository",
+				path:         "",
+				branch:       "",
+				provider:     "azure",
+				href:         "https://organization.visualstudio.com/DefaultCollection/project/_git/repository",
+			},
+			wantErr: false,
+		},
+		{
+			input: "git@ssh.dev.azure.com:v3/organization/project/repository",
+			want: &repository{
+				protocol:     "git",
+				protocols:    []string{"git"},
+				resource:     "ssh.dev.azure.com",
+				organization: "organization",
+				project:      "project",
+				owner:        "organization",
+				repo:         "repository",
+				path:         "",
+				branch:       "",
+				provider:     "azure",
+				href:         "git@ssh.dev.azure.com:v3/organization/project/repository",
+			},
+			wantErr: false,
+		},
+		{
+			input: "organization@vs-ssh.visualstudio.com:v3/organization/project/repository",
+			want: &repository{
+				protocol:     "git",
+				protocols:    []string{"git"},
+				resource:     "vs-ssh.visualstudio.com",
+				organization: "organization",
+				project:      "project",
+				owner:        "organization",
+				repo:         "repository",
+				path:         "",
+				branch:       "",
+				provider:     "azure",
+				href:         "organization@vs-ssh.visualstudio.com:v3/organization/project/repository",
+			},
+			wantErr: false,
+		},
+		{
+			input: "ssh://organization@vs-ssh.visualstudio.com/v3/organization/project/repository",
+			want: &repository{
+				protocol:     "ssh",
+				protocols:    []string{"ssh"},
+				resource:     "vs-ssh.visualstudio.com",
+				organization: "organization",
+				project:      "project",
+				owner:        "organization",
+				repo:         "repository",
+				path:         "",
+				branch:       "",
+				provider:     "azure",
+				href:         "ssh://organization@vs-ssh.visualstudio.com/v3/organization/project/repository",
+			},
+			wantErr: false,
+		},
+		// failure cases
+		{
+			input:   "https://dev.azure.com/",
+			want:    &repository{},
+			wantErr: true,
+		},
+		{
+			input:   "https://dev.azure.com/organization/project/repository",
+			want:    &repository{},
+			wantErr: true,
+		},
+		{
+			input:   "git@ssh.dev.azure.com:organization/project/repository",
+			want:    &repository{},
+			wantErr: true,
+		},
+		{
+			input:   "organization@vs-ssh.visualstudio.com:organization/project/repository",
+			want:    &repository{},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		var got pkg.RepositoryManager
+		got, err := suite.rm.Parse(tc.input)
+
+		if tc.wantErr {
+			assert.Error(suite.T(), err)
+		} else {
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tc.want.protocol, got.GetProtocol())
+			assert.Equal(suite.T(), tc.want.protocols, got.GetProtocols())
+			assert.Equal(suite.T(), tc.want.resource, got.GetResourceName())
+			assert.Equal(suite.T(), tc.want.href, got.GetHREF())
+			assert.Equal(suite.T(), tc.want.organization, got.GetOrganizationName())
+			assert.Equal(suite.T(), tc.want.project, got.GetProjectName())
+			assert.Equal(suite.T(), tc.want.owner, got.GetOwnerName())
+			assert.Equal(suite.T(), tc.want.repo, got.GetRepoName())
+			assert.Equal(suite.T(), tc.want.path, got.GetPath())
+			assert.Equal(suite.T(), tc.want.branch, got.GetBranchName())
+			assert.Equal(suite.T(), tc.want.provider, got.GetProviderName())
+		}
+	}
+}
+
+func (suite *ParserPublicTestSuite) TestParseLines() {
+	type test struct {
+		input     string
+		wantStart int
+		wantEnd   int
+	}
+
+	base := "https://dev.azure.com/organization/project/_git/repository?path=/main.go&version=GBdev"
+	tests := []test{
+		{
+			input:     base + "&line=10&lineEnd=21&lineStartColumn=1&lineEndColumn=1",
+			wantStart: 10,
+			wantEnd:   20,
+		},
+		{
+			input:     base + "&line=10&lineEnd=20&lineStartColumn=1&lineEndColumn=8",
+			wantStart: 10,
+			wantEnd:   20,
+		},
+		{
+			input:     base + "&line=10",
+			wantStart: 10,
+			wantEnd:   10,
+		},
+		{
+			input:     base,
+			wantStart: 0,
+			wantEnd:   0,
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := suite.rm.Parse(tc.input)
+		require.NoError(suite.T(), err)
+
+		assert.Equal(suite.T(), tc.wantStart, got.LineStart, tc.input)
+		assert.Equal(suite.T(), tc.wantEnd, got.LineEnd, tc.input)
+	}
+}
+
+// In order for `go test` to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run.
+func TestParserPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(ParserPublicTestSuite))
+}
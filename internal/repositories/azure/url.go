@@ -0,0 +1,137 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package azure
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/retr0h/git-url-parse/internal/repositories"
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+const (
+	sshUser string = "git"
+)
+
+// CloneRemote return the parts of the Azure DevOps repo's clone URL.  SSH
+// URLs follow the `v3/org/project/repo` layout of `ssh.dev.azure.com`.
+func (a *Azure) CloneRemote(
+	repo *api.Repository,
+	format api.CloneFormat,
+) (api.Remote, error) {
+	switch format {
+	case api.CloneFormatSCP, api.CloneFormatSSH, api.CloneFormatGitSSH:
+		return api.Remote{
+			User: sshUser,
+			Host: sshHost,
+			Path: fmt.Sprintf("v3/%s/%s/%s", repo.Organization, repo.Project, repo.Repo),
+		}, nil
+	case api.CloneFormatHTTPS:
+		// legacy hosts name the organization
+		if strings.HasSuffix(repo.Resource, legacyHost) {
+			return api.Remote{
+				Host: repo.Resource,
+				Path: fmt.Sprintf("%s/_git/%s", repo.Project, repo.Repo),
+			}, nil
+		}
+
+		return api.Remote{
+			Host: defaultHost,
+			Path: fmt.Sprintf("%s/%s/_git/%s", repo.Organization, repo.Project, repo.Repo),
+		}, nil
+	}
+
+	return api.Remote{}, fmt.Errorf("clone format: %s not supported by provider: %s", format, providerName)
+}
+
+// versionPrefixes the version prefix of each kind of ref, as in `GBmain`.
+var versionPrefixes = map[api.RefKind]string{
+	api.RefKindBranch: "GB",
+	api.RefKindTag:    "GT",
+	api.RefKindCommit: "GC",
+}
+
+// WebURL return the URL of the Azure DevOps repo's web page, which names the
+// path and version in its query, as in `?path=/README.md&version=GBmain`.
+// Raw files are served by the REST API's `items` endpoint.
+func (a *Azure) WebURL(
+	repo *api.Repository,
+	view api.View,
+	location api.Location,
+) (string, error) {
+	// legacy hosts name the organization
+	base := fmt.Sprintf("%s/%s/%s", repositories.WebBase(repo, defaultHost), repo.Organization, repo.Project)
+	if strings.HasSuffix(repo.Resource, legacyHost) {
+		base = fmt.Sprintf("%s/%s", repositories.WebBase(repo, repo.Resource), repo.Project)
+	}
+
+	query := url.Values{}
+	if location.Path != "" {
+		query.Set("path", "/"+strings.TrimPrefix(location.Path, "/"))
+	}
+
+	kind := repositories.LocationRefKind(location)
+	version := versionPrefixes[kind] + location.Ref
+
+	switch view {
+	case api.ViewTree:
+		query.Set("version", version)
+	case api.ViewBlob:
+		query.Set("version", version)
+		setLines(query, location)
+	case api.ViewHistory:
+		query.Set("version", version)
+		query.Set("_a", "history")
+	case api.ViewRaw:
+		query.Set("versionDescriptor.version", location.Ref)
+		if kind != api.RefKindBranch {
+			query.Set("versionDescriptor.versionType", string(kind))
+		}
+		query.Set("download", "true")
+
+		return fmt.Sprintf("%s/_apis/git/repositories/%s/items?%s", base, repo.Repo, query.Encode()), nil
+	case api.ViewCommit:
+		return fmt.Sprintf("%s/_git/%s/commit/%s", base, repo.Repo, url.PathEscape(location.Ref)), nil
+	default:
+		return "", fmt.Errorf("view: %s not supported by provider: %s", view, providerName)
+	}
+
+	return fmt.Sprintf("%s/_git/%s?%s", base, repo.Repo, query.Encode()), nil
+}
+
+// setLines select the location's lines, as in
+// `?line=10&lineEnd=21&lineStartColumn=1&lineEndColumn=1`; the selection ends
+// on the first column of the line following the range.
+func setLines(query url.Values, location api.Location) {
+	if location.LineStart == 0 {
+		return
+	}
+
+	end := max(location.LineStart, location.LineEnd)
+	query.Set("line", strconv.Itoa(location.LineStart))
+	query.Set("lineEnd", strconv.Itoa(end+1))
+	query.Set("lineStartColumn", "1")
+	query.Set("lineEndColumn", "1")
+	query.Set("lineStyle", "plain")
+	query.Set("_a", "contents")
+}
@@ -21,51 +21,137 @@
 package bitbucket
 
 import (
-	"fmt"
+	"context"
 	"log/slog"
-	"regexp"
+	"strings"
 
 	"github.com/retr0h/git-url-parse/internal/repositories"
 	"github.com/retr0h/git-url-parse/pkg/api"
+	"github.com/retr0h/git-url-parse/pkg/giturl"
 )
 
 const (
 	providerName string = "bitbucket"
+	// host matches Bitbucket Cloud's single host.
+	host string = `^bitbucket\.org$`
+	// repoPath matches a web URL's path up to the repo.
+	repoPath string = `^/(?P<owner>[^/]+)/(?P<repo>[^/]+?)`
+)
+
+var (
+	webSchemes = []string{"https"}
+	sshSchemes = []string{"ssh", "git+ssh", "git"}
+	scpSchemes = []string{repositories.SchemeSCPLike}
 )
 
 // ChatGPT-4 generated regexp
-var patterns = []string{
-	`^(?P<scheme>https)://(?P<resource>bitbucket\.org)/(?P<owner>[^/]+)/(?P<repo>[^/]+)(?:/(?P<type>src|raw)/(?P<branch>[^/]+)(/(?P<path>.*))?)?$`,
-	`^(?P<scheme>git)@(?P<resource>bitbucket\.org):(?P<owner>[^/]+)/(?P<repo>[^/]+)\.git$`,
+var patterns = repositories.MustCompile([]repositories.Pattern{
+	{Schemes: webSchemes, Host: host, Path: repoPath + `(?:\.git)?(?:/(?P<kind>src|raw|annotate|history-node)/(?P<branch>[^/]+)(/(?P<path>.*))?)?$`},
+	{Schemes: webSchemes, Host: host, Path: repoPath + `/(?P<kind>pull-requests|issues)(?:/(?P<number>\d+)(?:/.*)?)?$`},
+	{Schemes: webSchemes, Host: host, Path: repoPath + `/(?P<kind>commits)/(?P<commit>[0-9a-fA-F]+)$`},
+	{Schemes: webSchemes, Host: host, Path: repoPath + `/branches/(?P<kind>compare)/(?P<compare>.+)$`},
+	{Schemes: webSchemes, Host: host, Path: repoPath + `/pipelines/(?P<kind>results)/(?P<number>\d+)(?:/.*)?$`},
+	{Schemes: webSchemes, Host: host, Path: repoPath + `/(?P<kind>wiki)(?:/(?P<path>.+))?$`},
+	{Schemes: sshSchemes, Host: host, Path: `^/(?P<owner>[^/]+)/(?P<repo>[^/]+?)(?:\.git)?$`},
+	{Schemes: scpSchemes, Host: host, Path: `^(?P<owner>[^/]+)/(?P<repo>[^/]+)\.git$`},
+})
+
+// kinds the kind of page named by each pattern's `kind`, clone URLs name
+// none.  Source pages serve both trees and blobs, see sourceKind.
+var kinds = map[string]api.Kind{
+	"":              api.KindRepo,
+	"raw":           api.KindRaw,
+	"annotate":      api.KindBlame,
+	"history-node":  api.KindHistory,
+	"commits":       api.KindCommit,
+	"pull-requests": api.KindPullRequest,
+	"issues":        api.KindIssue,
+	"compare":       api.KindCompare,
+	"results":       api.KindRun,
+	"wiki":          api.KindWiki,
 }
 
 // Parse the provided Bitbucket URL.
 func (b *Bitbucket) Parse(url string) (*api.Repository, error) {
+	return repositories.Parse(b, url)
+}
+
+// ParseURL parse the tokens of the provided, redacted, Bitbucket URL.
+func (b *Bitbucket) ParseURL(url string, u *giturl.URL) (*api.Repository, error) {
 	for _, pattern := range patterns {
-		re := regexp.MustCompile(pattern)
-		matches := re.FindStringSubmatch(url)
-		mm := repositories.MakeMatchMap(re, matches)
+		mm := pattern.Match(u)
 
-		b.logger.Debug(
+		b.logger.LogAttrs(
+			context.Background(),
+			slog.LevelDebug,
 			"matching url",
 			slog.String("url", url),
-			slog.String("regexp", pattern),
+			slog.String("regexp", pattern.Path),
 		)
 
-		if matches != nil {
+		if mm != nil {
+			branch, ref, path := repositories.MatchRef(mm)
+			base, head := splitCompare(mm["compare"])
+
+			kind := kinds[mm["kind"]]
+			if mm["kind"] == "src" {
+				kind = sourceKind(mm["path"])
+			}
+
+			lineStart, lineEnd := repositories.FileLines(
+				repositories.IsFileKind(kind),
+				repositories.LinesBitbucket,
+				u.Fragment,
+			)
+
 			return &api.Repository{
-				Protocol: mm["scheme"],
-				Host:     mm["resource"],
-				Provider: providerName,
-				Resource: mm["resource"],
-				Owner:    mm["owner"],
-				Repo:     mm["repo"],
-				Path:     mm["path"],
-				Branch:   mm["branch"],
-				HREF:     url,
+				Protocol:  repositories.Protocol(u),
+				Host:      u.Host,
+				Provider:  providerName,
+				Resource:  u.Host,
+				Owner:     mm["owner"],
+				Repo:      mm["repo"],
+				Path:      path,
+				LineStart: lineStart,
+				LineEnd:   lineEnd,
+				Branch:    branch,
+				Ref:       ref,
+				Kind:      kind,
+				Number:    mm["number"],
+				Commit:    mm["commit"],
+				Base:      base,
+				Head:      head,
+				User:      u.User,
+				Port:      u.Port,
+				Pathname:  u.Path,
+				HREF:      url,
+
+				// branches may contain `/`, and run into the path
+				RefAmbiguous: repositories.IsRefAmbiguous(ref, path),
 			}, nil
 		}
 	}
 
-	return nil, fmt.Errorf("could match url: %s to any pattern", url)
+	return nil, repositories.NoMatch(url, u, providerName, patterns)
+}
+
+// sourceKind return the kind of a source page, which serves both trees and
+// blobs.  Paths naming no file, or ending with `/`, are reported as trees.
+func sourceKind(path string) api.Kind {
+	if path == "" || strings.HasSuffix(path, "/") {
+		return api.KindTree
+	}
+
+	return api.KindBlob
+}
+
+// splitCompare split a compare spec into its base and head.  Branch compare
+// pages name the head first, separated by an encoded carriage return, as in
+// `feature%0Dmain`.
+func splitCompare(spec string) (string, string) {
+	if head, base, ok := strings.Cut(spec, "%0D"); ok {
+		return base, head
+	}
+
+	return repositories.SplitCompare(spec)
 }
@@ -32,6 +32,7 @@ import (
 	"github.com/retr0h/git-url-parse/internal"
 	"github.com/retr0h/git-url-parse/internal/repositories/bitbucket"
 	"github.com/retr0h/git-url-parse/pkg"
+	"github.com/retr0h/git-url-parse/pkg/api"
 )
 
 type ParserPublicTestSuite struct {
@@ -152,7 +153,7 @@ func (suite *ParserPublicTestSuite) TestParse() {
 				owner:     "owner",
 				repo:      "repository",
 				path:      "README.md",
-				branch:    "4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a",
+				branch:    "",
 				provider:  "bitbucket",
 				href:      "https://bitbucket.org/owner/repository/raw/4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a/README.md",
 			},
@@ -161,6 +162,21 @@ func (suite *ParserPublicTestSuite) TestParse() {
 		// scp-like syntax supported by git for ssh
 		// see: https://mirrors.edge.kernel.org/pub/software/scm/git/docs/git-clone.html#URLS
 		// regular form
+		{
+			input: "ssh://git@bitbucket.org:22/owner/repository.git",
+			want: &repository{
+				protocol:  "ssh",
+				protocols: []string{"ssh"},
+				resource:  "bitbucket.org",
+				owner:     "owner",
+				repo:      "repository",
+				path:      "",
+				branch:    "",
+				provider:  "bitbucket",
+				href:      "ssh://git@bitbucket.org:22/owner/repository.git",
+			},
+			wantErr: false,
+		},
 		{
 			input: "git@bitbucket.org:owner/repository.git",
 			want: &repository{
@@ -210,6 +226,110 @@ func (suite *ParserPublicTestSuite) TestParse() {
 	}
 }
 
+func (suite *ParserPublicTestSuite) TestParseKind() {
+	type test struct {
+		input  string
+		kind   api.Kind
+		number string
+		commit string
+		base   string
+		head   string
+		tag    string
+		branch string
+		path   string
+	}
+
+	tests := []test{
+		{
+			input: "git@bitbucket.org:o/r.git",
+			kind:  api.KindRepo,
+		},
+		{
+			input:  "https://bitbucket.org/o/r/src/main/",
+			kind:   api.KindTree,
+			branch: "main",
+			path:   "",
+		},
+		{
+			input:  "https://bitbucket.org/o/r/src/main/docs/",
+			kind:   api.KindTree,
+			branch: "main",
+			path:   "docs/",
+		},
+		{
+			input:  "https://bitbucket.org/o/r/src/main/x.go",
+			kind:   api.KindBlob,
+			branch: "main",
+			path:   "x.go",
+		},
+		{
+			input:  "https://bitbucket.org/o/r/raw/main/x.go",
+			kind:   api.KindRaw,
+			branch: "main",
+			path:   "x.go",
+		},
+		{
+			input:  "https://bitbucket.org/o/r/annotate/main/x.go",
+			kind:   api.KindBlame,
+			branch: "main",
+			path:   "x.go",
+		},
+		{
+			input:  "https://bitbucket.org/o/r/history-node/main/x.go",
+			kind:   api.KindHistory,
+			branch: "main",
+			path:   "x.go",
+		},
+		{
+			input:  "https://bitbucket.org/o/r/pull-requests/12/diff",
+			kind:   api.KindPullRequest,
+			number: "12",
+		},
+		{
+			input:  "https://bitbucket.org/o/r/issues/34",
+			kind:   api.KindIssue,
+			number: "34",
+		},
+		{
+			input:  "https://bitbucket.org/o/r/commits/4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a",
+			kind:   api.KindCommit,
+			commit: "4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a",
+		},
+		{
+			input: "https://bitbucket.org/o/r/branches/compare/feature%0Dmain#diff",
+			kind:  api.KindCompare,
+			base:  "main",
+			head:  "feature",
+		},
+		{
+			input:  "https://bitbucket.org/o/r/pipelines/results/56",
+			kind:   api.KindRun,
+			number: "56",
+		},
+		{
+			input: "https://bitbucket.org/o/r/wiki/Home",
+			kind:  api.KindWiki,
+			path:  "Home",
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := suite.rm.Parse(tc.input)
+		require.NoError(suite.T(), err, tc.input)
+
+		assert.Equal(suite.T(), tc.kind, got.GetKind(), tc.input)
+		assert.Equal(suite.T(), tc.number, got.GetNumber(), tc.input)
+		assert.Equal(suite.T(), tc.commit, got.GetCommit(), tc.input)
+		assert.Equal(suite.T(), tc.base, got.GetBase(), tc.input)
+		assert.Equal(suite.T(), tc.head, got.GetHead(), tc.input)
+		if tc.tag != "" {
+			assert.Equal(suite.T(), api.Ref{Kind: api.RefKindTag, Name: tc.tag}, got.GetRef(), tc.input)
+		}
+		assert.Equal(suite.T(), tc.branch, got.GetBranchName(), tc.input)
+		assert.Equal(suite.T(), tc.path, got.GetPath(), tc.input)
+	}
+}
+
 // In order for `go test` to run this suite, we need to create
 // a normal test function and pass our suite to suite.Run.
 func TestParserPublicTestSuite(t *testing.T) {
@@ -0,0 +1,80 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package bitbucket
+
+import (
+	"fmt"
+
+	"github.com/retr0h/git-url-parse/internal/repositories"
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+// views the path segment of each web page.
+var views = map[api.View]string{
+	api.ViewTree:    "src",
+	api.ViewBlob:    "src",
+	api.ViewRaw:     "raw",
+	api.ViewBlame:   "annotate",
+	api.ViewHistory: "history-node",
+	api.ViewCommit:  "commits",
+}
+
+// CloneRemote return the parts of the Bitbucket Cloud repo's clone URL, following
+// the layout of `api.Repository.DefaultRemote`.  The git protocol is not
+// served.
+func (b *Bitbucket) CloneRemote(
+	repo *api.Repository,
+	format api.CloneFormat,
+) (api.Remote, error) {
+	if format == api.CloneFormatGit {
+		return api.Remote{}, fmt.Errorf("clone format: %s not supported by provider: %s", format, providerName)
+	}
+
+	return repo.DefaultRemote(format)
+}
+
+// WebURL return the URL of the Bitbucket repo's web page, as in
+// `https://bitbucket.org/owner/repo/src/main/README.md`, anchoring lines as in
+// `#lines-10:20`.
+func (b *Bitbucket) WebURL(
+	repo *api.Repository,
+	view api.View,
+	location api.Location,
+) (string, error) {
+	segment, ok := views[view]
+	if !ok {
+		return "", fmt.Errorf("view: %s not supported by provider: %s", view, providerName)
+	}
+
+	revision := location.Ref + "/" + location.Path
+	if view == api.ViewCommit {
+		revision = location.Ref
+	}
+
+	return fmt.Sprintf("%s/%s/%s/%s/%s%s",
+		repositories.WebBase(repo, defaultHost),
+		repo.Owner,
+		repo.Repo,
+		segment,
+		repositories.EscapePath(revision),
+		repositories.LineAnchor(view, location, "#lines-%d", "#lines-%d:%d"),
+	), nil
+}
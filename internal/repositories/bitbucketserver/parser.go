@@ -0,0 +1,120 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package bitbucketserver
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"github.com/retr0h/git-url-parse/internal/repositories"
+	"github.com/retr0h/git-url-parse/pkg/api"
+	"github.com/retr0h/git-url-parse/pkg/giturl"
+)
+
+const (
+	providerName string = "bitbucket-server"
+)
+
+var patterns = repositories.MustCompile([]repositories.Pattern{
+	{
+		Schemes: []string{"http", "https"},
+		Path:    `^/scm/(?P<owner>[^/]+)/(?P<repo>[^/]+?)(?:\.git)?$`,
+	},
+	{
+		Schemes: []string{"http", "https"},
+		Path:    `^/(?:projects/(?P<owner>[^/]+)|users/~?(?P<user>[^/]+))/repos/(?P<repo>[^/]+)(?:/browse(?:/(?P<path>.*))?)?/?$`,
+	},
+	{
+		Schemes: []string{"ssh", "git+ssh"},
+		Path:    `^/(?P<owner>[^/]+)/(?P<repo>[^/]+?)(?:\.git)?$`,
+	},
+})
+
+// Parse the provided Bitbucket Server URL.
+func (b *BitbucketServer) Parse(url string) (*api.Repository, error) {
+	return repositories.Parse(b, url)
+}
+
+// ParseURL parse the tokens of the provided, redacted, Bitbucket Server URL.
+func (b *BitbucketServer) ParseURL(url string, u *giturl.URL) (*api.Repository, error) {
+	for _, pattern := range patterns {
+		mm := pattern.Match(u)
+
+		b.logger.LogAttrs(
+			context.Background(),
+			slog.LevelDebug,
+			"matching url",
+			slog.String("url", url),
+			slog.String("regexp", pattern.Path),
+		)
+
+		if mm != nil {
+			ref := parseQuery(u.Query)
+
+			// personal repositories are keyed by the user's name
+			owner := mm["owner"]
+			if mm["user"] != "" {
+				owner = "~" + mm["user"]
+			}
+
+			path := repositories.DecodePath(strings.TrimSuffix(mm["path"], "/"))
+			lineStart, lineEnd := repositories.FileLines(
+				path != "",
+				repositories.LinesNumeric,
+				u.Fragment,
+			)
+
+			return &api.Repository{
+				Protocol:  u.Scheme,
+				Host:      u.Host,
+				Provider:  providerName,
+				Resource:  u.Host,
+				Owner:     owner,
+				Project:   owner,
+				Repo:      mm["repo"],
+				Path:      path,
+				LineStart: lineStart,
+				LineEnd:   lineEnd,
+				Branch:    repositories.BranchName(ref),
+				Ref:       ref,
+				User:      u.User,
+				Port:      u.Port,
+				Pathname:  u.Path,
+				HREF:      url,
+			}, nil
+		}
+	}
+
+	return nil, repositories.NoMatch(url, u, providerName, patterns)
+}
+
+// parseQuery return the typed reference from a browse URL's query string, as
+// in `?at=refs/heads/main`.
+func parseQuery(query string) api.Ref {
+	values, err := url.ParseQuery(query)
+	if err != nil || values.Get("at") == "" {
+		return api.Ref{}
+	}
+
+	return repositories.ClassifyRef(values.Get("at"))
+}
@@ -0,0 +1,276 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package bitbucketserver_test
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/retr0h/git-url-parse/internal"
+	"github.com/retr0h/git-url-parse/internal/repositories/bitbucketserver"
+	"github.com/retr0h/git-url-parse/pkg"
+)
+
+type ParserPublicTestSuite struct {
+	suite.Suite
+
+	rm internal.ParserManager
+
+	logger *slog.Logger
+}
+
+func (suite *ParserPublicTestSuite) SetupTest() {
+	suite.logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	suite.rm = bitbucketserver.New(suite.logger)
+}
+
+func (suite *ParserPublicTestSuite) TestParse() {
+	type repository struct {
+		branch    string
+		href      string
+		owner     string
+		path      string
+		project   string
+		protocol  string
+		protocols []string
+		provider  string
+		repo      string
+		resource  string
+	}
+
+	type test struct {
+		input   string
+		want    *repository
+		wantErr bool
+	}
+
+	tests := []test{
+		{
+			input: "https://bitbucket.example.com/scm/PROJ/repository.git",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "bitbucket.example.com",
+				href:      "https://bitbucket.example.com/scm/PROJ/repository.git",
+				owner:     "PROJ",
+				project:   "PROJ",
+				repo:      "repository",
+				path:      "",
+				branch:    "",
+				provider:  "bitbucket-server",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://user@bitbucket.example.com:8443/scm/PROJ/repository.git",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "bitbucket.example.com",
+				href:      "https://user@bitbucket.example.com:8443/scm/PROJ/repository.git",
+				owner:     "PROJ",
+				project:   "PROJ",
+				repo:      "repository",
+				path:      "",
+				branch:    "",
+				provider:  "bitbucket-server",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://bitbucket.example.com/scm/~name/repository.git",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "bitbucket.example.com",
+				href:      "https://bitbucket.example.com/scm/~name/repository.git",
+				owner:     "~name",
+				project:   "~name",
+				repo:      "repository",
+				path:      "",
+				branch:    "",
+				provider:  "bitbucket-server",
+			},
+			wantErr: false,
+		},
+		{
+			input: "ssh://git@bitbucket.example.com:7999/proj/repository.git",
+			want: &repository{
+				protocol:  "ssh",
+				protocols: []string{"ssh"},
+				resource:  "bitbucket.example.com",
+				href:      "ssh://git@bitbucket.example.com:7999/proj/repository.git",
+				owner:     "proj",
+				project:   "proj",
+				repo:      "repository",
+				path:      "",
+				branch:    "",
+				provider:  "bitbucket-server",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://bitbucket.example.com/projects/PROJ/repos/repository",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "bitbucket.example.com",
+				href:      "https://bitbucket.example.com/projects/PROJ/repos/repository",
+				owner:     "PROJ",
+				project:   "PROJ",
+				repo:      "repository",
+				path:      "",
+				branch:    "",
+				provider:  "bitbucket-server",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://bitbucket.example.com/projects/PROJ/repos/repository/browse",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "bitbucket.example.com",
+				href:      "https://bitbucket.example.com/projects/PROJ/repos/repository/browse",
+				owner:     "PROJ",
+				project:   "PROJ",
+				repo:      "repository",
+				path:      "",
+				branch:    "",
+				provider:  "bitbucket-server",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://bitbucket.example.com/projects/PROJ/repos/repository/browse/src/main.go?at=refs/heads/dev",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "bitbucket.example.com",
+				href:      "https://bitbucket.example.com/projects/PROJ/repos/repository/browse/src/main.go?at=refs/heads/dev",
+				owner:     "PROJ",
+				project:   "PROJ",
+				repo:      "repository",
+				path:      "src/main.go",
+				branch:    "dev",
+				provider:  "bitbucket-server",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://bitbucket.example.com/projects/PROJ/repos/repository/browse/docs/?at=refs%2Ftags%2Fv1.0.0",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "bitbucket.example.com",
+				href:      "https://bitbucket.example.com/projects/PROJ/repos/repository/browse/docs/?at=refs%2Ftags%2Fv1.0.0",
+				owner:     "PROJ",
+				project:   "PROJ",
+				repo:      "repository",
+				path:      "docs",
+				branch:    "",
+				provider:  "bitbucket-server",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://bitbucket.example.com/users/~name/repos/repository/browse/README.md?at=main",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "bitbucket.example.com",
+				href:      "https://bitbucket.example.com/users/~name/repos/repository/browse/README.md?at=main",
+				owner:     "~name",
+				project:   "~name",
+				repo:      "repository",
+				path:      "README.md",
+				branch:    "main",
+				provider:  "bitbucket-server",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://bitbucket.example.com/users/name/repos/repository",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "bitbucket.example.com",
+				href:      "https://bitbucket.example.com/users/name/repos/repository",
+				owner:     "~name",
+				project:   "~name",
+				repo:      "repository",
+				path:      "",
+				branch:    "",
+				provider:  "bitbucket-server",
+			},
+			wantErr: false,
+		},
+		// failure cases
+		{
+			input:   "https://bitbucket.example.com/",
+			want:    &repository{},
+			wantErr: true,
+		},
+		{
+			input:   "https://bitbucket.example.com/PROJ/repository",
+			want:    &repository{},
+			wantErr: true,
+		},
+		{
+			input:   "https://bitbucket.example.com/projects/PROJ",
+			want:    &repository{},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		var got pkg.RepositoryManager
+		got, err := suite.rm.Parse(tc.input)
+
+		if tc.wantErr {
+			assert.Error(suite.T(), err)
+		} else {
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tc.want.protocol, got.GetProtocol())
+			assert.Equal(suite.T(), tc.want.protocols, got.GetProtocols())
+			assert.Equal(suite.T(), tc.want.resource, got.GetResourceName())
+			assert.Equal(suite.T(), tc.want.href, got.GetHREF())
+			assert.Equal(suite.T(), tc.want.owner, got.GetOwnerName())
+			assert.Equal(suite.T(), tc.want.project, got.GetProjectName())
+			assert.Equal(suite.T(), tc.want.repo, got.GetRepoName())
+			assert.Equal(suite.T(), tc.want.path, got.GetPath())
+			assert.Equal(suite.T(), tc.want.branch, got.GetBranchName())
+			assert.Equal(suite.T(), tc.want.provider, got.GetProviderName())
+		}
+	}
+}
+
+// In order for `go test` to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run.
+func TestParserPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(ParserPublicTestSuite))
+}
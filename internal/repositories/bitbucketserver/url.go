@@ -0,0 +1,98 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package bitbucketserver
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/retr0h/git-url-parse/internal/repositories"
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+const (
+	defaultSSHPort string = "7999"
+)
+
+// CloneRemote return the parts of the Bitbucket Server repo's clone URL.
+// SSH is served on port 7999 unless the parsed URL names another, and HTTPS
+// under `/scm/`.  The scp-like format cannot name the port, and is not
+// served.
+func (b *BitbucketServer) CloneRemote(
+	repo *api.Repository,
+	format api.CloneFormat,
+) (api.Remote, error) {
+	remote, err := repo.DefaultRemote(format)
+	if err != nil {
+		return api.Remote{}, err
+	}
+
+	switch format {
+	case api.CloneFormatSSH, api.CloneFormatGitSSH:
+		if remote.Port == "" {
+			remote.Port = defaultSSHPort
+		}
+
+		return remote, nil
+	case api.CloneFormatHTTPS:
+		remote.Path = "scm/" + remote.Path
+
+		return remote, nil
+	}
+
+	return api.Remote{}, fmt.Errorf("clone format: %s not supported by provider: %s", format, providerName)
+}
+
+// WebURL return the URL of the Bitbucket Server repo's web page, which names
+// the ref in its query, as in
+// `/projects/KEY/repos/repo/browse/README.md?at=main`.  Lines are anchored as
+// in `#10-20`, and personal repos are served under `/users/`.  Blame is not
+// linkable, and history only for the whole repo, as in `/commits?until=main`,
+// not for a path.
+func (b *BitbucketServer) WebURL(
+	repo *api.Repository,
+	view api.View,
+	location api.Location,
+) (string, error) {
+	base := fmt.Sprintf("%s/projects/%s/repos/%s", repositories.WebBase(repo, repo.Resource), repo.Owner, repo.Repo)
+	if user, ok := strings.CutPrefix(repo.Owner, "~"); ok {
+		base = fmt.Sprintf("%s/users/%s/repos/%s", repositories.WebBase(repo, repo.Resource), user, repo.Repo)
+	}
+
+	at := url.QueryEscape(location.Ref)
+	path := repositories.EscapePath(location.Path)
+
+	switch view {
+	case api.ViewTree, api.ViewBlob:
+		return fmt.Sprintf("%s/browse/%s?at=%s%s",
+			base, path, at, repositories.LineAnchor(view, location, "#%d", "#%d-%d")), nil
+	case api.ViewRaw:
+		return fmt.Sprintf("%s/raw/%s?at=%s", base, path, at), nil
+	case api.ViewHistory:
+		if location.Path == "" {
+			return fmt.Sprintf("%s/commits?until=%s", base, at), nil
+		}
+	case api.ViewCommit:
+		return fmt.Sprintf("%s/commits/%s", base, url.PathEscape(location.Ref)), nil
+	}
+
+	return "", fmt.Errorf("view: %s not supported by provider: %s", view, providerName)
+}
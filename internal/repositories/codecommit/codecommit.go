@@ -0,0 +1,50 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package codecommit
+
+import (
+	"log/slog"
+	"strings"
+)
+
+const (
+	helperHost string = "codecommit"
+	hostPrefix string = "git-codecommit"
+	hostSuffix string = ".amazonaws.com"
+)
+
+// New factory to create a new CodeCommit instance.
+func New(
+	logger *slog.Logger,
+) *CodeCommit {
+	return &CodeCommit{
+		logger: logger,
+	}
+}
+
+// ShouldParse determine if the provided URL belongs to AWS CodeCommit.  URLs
+// using the `git-remote-codecommit` helper are dispatched on the helper's
+// name.
+func (c *CodeCommit) ShouldParse(host string) bool {
+	return host == helperHost ||
+		(strings.HasPrefix(host, hostPrefix) &&
+			strings.HasSuffix(strings.TrimSuffix(host, ".cn"), hostSuffix))
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package codecommit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/retr0h/git-url-parse/internal/repositories"
+	"github.com/retr0h/git-url-parse/pkg/api"
+	"github.com/retr0h/git-url-parse/pkg/giturl"
+)
+
+const (
+	providerName string = "codecommit"
+)
+
+var patterns = repositories.MustCompile([]repositories.Pattern{
+	{
+		Schemes: []string{"https", "ssh", "git+ssh"},
+		Host:    `^git-codecommit(?:-fips)?\.(?P<region>[^./]+)\.amazonaws\.com(?:\.cn)?$`,
+		Path:    `^/v1/repos/(?P<repo>[^/]+?)/?$`,
+	},
+	{
+		Schemes: []string{helperHost},
+		Path:    `^$`,
+	},
+})
+
+// Parse the provided AWS CodeCommit URL.  CodeCommit repositories have no
+// owner; the region and profile are returned instead.
+func (c *CodeCommit) Parse(url string) (*api.Repository, error) {
+	return repositories.Parse(c, url)
+}
+
+// ParseURL parse the tokens of the provided, redacted, AWS CodeCommit URL.
+func (c *CodeCommit) ParseURL(url string, u *giturl.URL) (*api.Repository, error) {
+	for _, pattern := range patterns {
+		mm := pattern.Match(u)
+
+		c.logger.LogAttrs(
+			context.Background(),
+			slog.LevelDebug,
+			"matching url",
+			slog.String("url", url),
+			slog.String("regexp", pattern.Path),
+		)
+
+		if mm != nil {
+			if u.Scheme == helperHost || u.Helper == helperHost {
+				return parseHelper(u, url), nil
+			}
+
+			return &api.Repository{
+				Protocol: u.Scheme,
+				Host:     u.Host,
+				Provider: providerName,
+				Resource: u.Host,
+				Repo:     mm["repo"],
+				Region:   mm["region"],
+				User:     u.User,
+				Port:     u.Port,
+				Pathname: u.Path,
+				HREF:     url,
+			}, nil
+		}
+	}
+
+	return nil, repositories.NoMatch(url, u, providerName, patterns)
+}
+
+// parseHelper return the repo named by a `git-remote-codecommit` URL, as in
+// `codecommit::us-east-1://profile@repo`, which names the repo in place of
+// the host, and the profile in place of the user.  Only URLs naming the
+// region name a host.  The tokenizer lowercases hosts, so the repo's name is
+// read from the URL as written.
+func parseHelper(u *giturl.URL, url string) *api.Repository {
+	var region, resource string
+	if u.Helper != "" {
+		region = u.Scheme
+		resource = fmt.Sprintf("%s.%s%s", hostPrefix, region, hostSuffix)
+	}
+
+	return &api.Repository{
+		Protocol: helperHost,
+		Host:     resource,
+		Provider: providerName,
+		Resource: resource,
+		Repo:     helperRepo(url),
+		Region:   region,
+		Profile:  u.User,
+		HREF:     url,
+	}
+}
+
+// helperRepo return the repo named by a `git-remote-codecommit` URL, as
+// written, following its scheme and any profile.
+func helperRepo(url string) string {
+	_, address, _ := strings.Cut(url, "://")
+	if i := strings.IndexAny(address, "?#"); i != -1 {
+		address = address[:i]
+	}
+	if i := strings.LastIndex(address, "@"); i != -1 {
+		address = address[i+1:]
+	}
+
+	return address
+}
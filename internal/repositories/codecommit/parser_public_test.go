@@ -0,0 +1,264 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package codecommit_test
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/retr0h/git-url-parse/internal"
+	"github.com/retr0h/git-url-parse/internal/repositories/codecommit"
+	"github.com/retr0h/git-url-parse/pkg"
+)
+
+type ParserPublicTestSuite struct {
+	suite.Suite
+
+	rm internal.ParserManager
+
+	logger *slog.Logger
+}
+
+func (suite *ParserPublicTestSuite) SetupTest() {
+	suite.logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	suite.rm = codecommit.New(suite.logger)
+}
+
+func (suite *ParserPublicTestSuite) TestParse() {
+	type repository struct {
+		href      string
+		owner     string
+		profile   string
+		protocol  string
+		protocols []string
+		provider  string
+		region    string
+		repo      string
+		resource  string
+	}
+
+	type test struct {
+		input   string
+		want    *repository
+		wantErr bool
+	}
+
+	tests := []test{
+		{
+			input: "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/repository",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "git-codecommit.us-east-1.amazonaws.com",
+				href:      "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/repository",
+				owner:     "",
+				repo:      "repository",
+				region:    "us-east-1",
+				profile:   "",
+				provider:  "codecommit",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://git-codecommit-fips.us-gov-west-1.amazonaws.com/v1/repos/repository",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "git-codecommit-fips.us-gov-west-1.amazonaws.com",
+				href:      "https://git-codecommit-fips.us-gov-west-1.amazonaws.com/v1/repos/repository",
+				owner:     "",
+				repo:      "repository",
+				region:    "us-gov-west-1",
+				profile:   "",
+				provider:  "codecommit",
+			},
+			wantErr: false,
+		},
+		{
+			input: "ssh://git-codecommit.us-east-1.amazonaws.com/v1/repos/repository",
+			want: &repository{
+				protocol:  "ssh",
+				protocols: []string{"ssh"},
+				resource:  "git-codecommit.us-east-1.amazonaws.com",
+				href:      "ssh://git-codecommit.us-east-1.amazonaws.com/v1/repos/repository",
+				owner:     "",
+				repo:      "repository",
+				region:    "us-east-1",
+				profile:   "",
+				provider:  "codecommit",
+			},
+			wantErr: false,
+		},
+		{
+			input: "ssh://APKAEIBAERJR2EXAMPLE@git-codecommit.eu-west-1.amazonaws.com/v1/repos/repository",
+			want: &repository{
+				protocol:  "ssh",
+				protocols: []string{"ssh"},
+				resource:  "git-codecommit.eu-west-1.amazonaws.com",
+				href:      "ssh://APKAEIBAERJR2EXAMPLE@git-codecommit.eu-west-1.amazonaws.com/v1/repos/repository",
+				owner:     "",
+				repo:      "repository",
+				region:    "eu-west-1",
+				profile:   "",
+				provider:  "codecommit",
+			},
+			wantErr: false,
+		},
+		{
+			input: "codecommit::us-east-1://profile@MyRepository",
+			want: &repository{
+				protocol:  "codecommit",
+				protocols: []string{"codecommit"},
+				resource:  "git-codecommit.us-east-1.amazonaws.com",
+				href:      "codecommit::us-east-1://profile@MyRepository",
+				owner:     "",
+				repo:      "MyRepository",
+				region:    "us-east-1",
+				profile:   "profile",
+				provider:  "codecommit",
+			},
+			wantErr: false,
+		},
+		{
+			input: "codecommit://MyRepository",
+			want: &repository{
+				protocol:  "codecommit",
+				protocols: []string{"codecommit"},
+				resource:  "",
+				href:      "codecommit://MyRepository",
+				owner:     "",
+				repo:      "MyRepository",
+				region:    "",
+				profile:   "",
+				provider:  "codecommit",
+			},
+			wantErr: false,
+		},
+		{
+			input: "codecommit::us-east-1://profile@repository",
+			want: &repository{
+				protocol:  "codecommit",
+				protocols: []string{"codecommit"},
+				resource:  "git-codecommit.us-east-1.amazonaws.com",
+				href:      "codecommit::us-east-1://profile@repository",
+				owner:     "",
+				repo:      "repository",
+				region:    "us-east-1",
+				profile:   "profile",
+				provider:  "codecommit",
+			},
+			wantErr: false,
+		},
+		{
+			input: "codecommit::us-east-1://repository",
+			want: &repository{
+				protocol:  "codecommit",
+				protocols: []string{"codecommit"},
+				resource:  "git-codecommit.us-east-1.amazonaws.com",
+				href:      "codecommit::us-east-1://repository",
+				owner:     "",
+				repo:      "repository",
+				region:    "us-east-1",
+				profile:   "",
+				provider:  "codecommit",
+			},
+			wantErr: false,
+		},
+		{
+			input: "codecommit://profile@repository",
+			want: &repository{
+				protocol:  "codecommit",
+				protocols: []string{"codecommit"},
+				resource:  "",
+				href:      "codecommit://profile@repository",
+				owner:     "",
+				repo:      "repository",
+				region:    "",
+				profile:   "profile",
+				provider:  "codecommit",
+			},
+			wantErr: false,
+		},
+		{
+			input: "codecommit://repository",
+			want: &repository{
+				protocol:  "codecommit",
+				protocols: []string{"codecommit"},
+				resource:  "",
+				href:      "codecommit://repository",
+				owner:     "",
+				repo:      "repository",
+				region:    "",
+				profile:   "",
+				provider:  "codecommit",
+			},
+			wantErr: false,
+		},
+		// failure cases
+		{
+			input:   "https://git-codecommit.us-east-1.amazonaws.com/",
+			want:    &repository{},
+			wantErr: true,
+		},
+		{
+			input:   "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/owner/repository",
+			want:    &repository{},
+			wantErr: true,
+		},
+		{
+			input:   "codecommit::us-east-1://profile@owner/repository",
+			want:    &repository{},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		var got pkg.RepositoryManager
+		got, err := suite.rm.Parse(tc.input)
+
+		if tc.wantErr {
+			assert.Error(suite.T(), err)
+		} else {
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tc.want.protocol, got.GetProtocol())
+			assert.Equal(suite.T(), tc.want.protocols, got.GetProtocols())
+			assert.Equal(suite.T(), tc.want.resource, got.GetResourceName())
+			assert.Equal(suite.T(), tc.want.href, got.GetHREF())
+			assert.Equal(suite.T(), tc.want.owner, got.GetOwnerName())
+			assert.Equal(suite.T(), tc.want.repo, got.GetRepoName())
+			assert.Equal(suite.T(), tc.want.region, got.GetRegionName())
+			assert.Equal(suite.T(), tc.want.profile, got.GetProfileName())
+			assert.Equal(suite.T(), tc.want.provider, got.GetProviderName())
+		}
+	}
+}
+
+// In order for `go test` to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run.
+func TestParserPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(ParserPublicTestSuite))
+}
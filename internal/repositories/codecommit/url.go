@@ -0,0 +1,98 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package codecommit
+
+import (
+	"fmt"
+
+	"github.com/retr0h/git-url-parse/internal/repositories"
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+// CloneRemote return the parts of the AWS CodeCommit repo's clone URL, as in
+// `https://git-codecommit.us-east-1.amazonaws.com/v1/repos/repo`.  SSH URLs
+// keep the parsed SSH key ID as the user.
+func (c *CodeCommit) CloneRemote(
+	repo *api.Repository,
+	format api.CloneFormat,
+) (api.Remote, error) {
+	if repo.Resource == "" || repo.Repo == "" {
+		return api.Remote{}, fmt.Errorf("repo: %s has no host or name to format", repo.HREF)
+	}
+
+	remote := api.Remote{
+		Host: repo.Resource,
+		Path: "v1/repos/" + repo.Repo,
+	}
+
+	switch format {
+	case api.CloneFormatSSH, api.CloneFormatGitSSH:
+		if repo.Protocol == "ssh" {
+			remote.User = repo.User
+		}
+
+		return remote, nil
+	case api.CloneFormatHTTPS:
+		return remote, nil
+	}
+
+	return api.Remote{}, fmt.Errorf("clone format: %s not supported by provider: %s", format, providerName)
+}
+
+// WebURL return the URL of the AWS CodeCommit repo's page in the AWS console,
+// as in `https://us-east-1.console.aws.amazon.com/codesuite/codecommit/...`.
+// Only the browse and commit pages are served.
+func (c *CodeCommit) WebURL(
+	repo *api.Repository,
+	view api.View,
+	location api.Location,
+) (string, error) {
+	if repo.Region == "" {
+		return "", fmt.Errorf("repo: %s has no region to link to", repo.HREF)
+	}
+
+	base := fmt.Sprintf(
+		"https://%s.console.aws.amazon.com/codesuite/codecommit/repositories/%s",
+		repo.Region,
+		repo.Repo,
+	)
+
+	switch view {
+	case api.ViewTree, api.ViewBlob:
+		ref := location.Ref
+		switch repositories.LocationRefKind(location) {
+		case api.RefKindBranch:
+			ref = "refs/heads/" + ref
+		case api.RefKindTag:
+			ref = "refs/tags/" + ref
+		}
+
+		page := "browse/" + repositories.EscapePath(ref)
+		if location.Path != "" {
+			page += "/--/" + repositories.EscapePath(location.Path)
+		}
+
+		return fmt.Sprintf("%s/%s?region=%s", base, page, repo.Region), nil
+	case api.ViewCommit:
+		return fmt.Sprintf("%s/commit/%s?region=%s", base, repositories.EscapePath(location.Ref), repo.Region), nil
+	}
+
+	return "", fmt.Errorf("view: %s not supported by provider: %s", view, providerName)
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package codecommit_test
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/retr0h/git-url-parse/internal/repositories/codecommit"
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+type URLPublicTestSuite struct {
+	suite.Suite
+
+	logger *slog.Logger
+}
+
+func (suite *URLPublicTestSuite) SetupTest() {
+	suite.logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
+}
+
+func (suite *URLPublicTestSuite) TestCloneRemote() {
+	type test struct {
+		input   api.CloneFormat
+		want    api.Remote
+		wantErr bool
+	}
+
+	repo := &api.Repository{
+		Protocol: "ssh",
+		Resource: "git-codecommit.us-east-1.amazonaws.com",
+		Repo:     "repo",
+		User:     "KEYID",
+		HREF:     "ssh://KEYID@git-codecommit.us-east-1.amazonaws.com/v1/repos/repo",
+	}
+
+	tests := []test{
+		{
+			input:   api.CloneFormatSSH,
+			want:    api.Remote{User: "KEYID", Host: "git-codecommit.us-east-1.amazonaws.com", Path: "v1/repos/repo"},
+			wantErr: false,
+		},
+		{
+			input:   api.CloneFormatHTTPS,
+			want:    api.Remote{Host: "git-codecommit.us-east-1.amazonaws.com", Path: "v1/repos/repo"},
+			wantErr: false,
+		},
+		// failure cases
+		{
+			input:   api.CloneFormatSCP,
+			want:    api.Remote{},
+			wantErr: true,
+		},
+		{
+			input:   api.CloneFormatGit,
+			want:    api.Remote{},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := codecommit.New(suite.logger).CloneRemote(repo, tc.input)
+
+		if tc.wantErr {
+			assert.Error(suite.T(), err)
+		} else {
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tc.want, got)
+		}
+	}
+}
+
+func (suite *URLPublicTestSuite) TestWebURL() {
+	type input struct {
+		view     api.View
+		location api.Location
+	}
+
+	type test struct {
+		input   *input
+		want    string
+		wantErr bool
+	}
+
+	repo := &api.Repository{
+		Resource: "git-codecommit.us-east-1.amazonaws.com",
+		Region:   "us-east-1",
+		Repo:     "repo",
+	}
+
+	tests := []test{
+		{
+			input:   &input{view: api.ViewTree, location: api.Location{Ref: "main"}},
+			want:    "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/repo/browse/refs/heads/main?region=us-east-1",
+			wantErr: false,
+		},
+		{
+			input:   &input{view: api.ViewBlob, location: api.Location{Ref: "main", Path: "README.md"}},
+			want:    "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/repo/browse/refs/heads/main/--/README.md?region=us-east-1",
+			wantErr: false,
+		},
+		{
+			input:   &input{view: api.ViewCommit, location: api.Location{Ref: "4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a"}},
+			want:    "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/repo/commit/4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a?region=us-east-1",
+			wantErr: false,
+		},
+		{
+			input:   &input{view: api.ViewTree, location: api.Location{Ref: "v1.0", RefKind: api.RefKindTag}},
+			want:    "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/repo/browse/refs/tags/v1.0?region=us-east-1",
+			wantErr: false,
+		},
+		// failure cases
+		{
+			input:   &input{view: api.ViewRaw, location: api.Location{Ref: "main", Path: "README.md"}},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			input:   &input{view: api.ViewHistory, location: api.Location{Ref: "main"}},
+			want:    "",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := codecommit.New(suite.logger).WebURL(repo, tc.input.view, tc.input.location)
+
+		if tc.wantErr {
+			assert.Error(suite.T(), err)
+		} else {
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tc.want, got)
+		}
+	}
+}
+
+// In order for `go test` to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run.
+func TestURLPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(URLPublicTestSuite))
+}
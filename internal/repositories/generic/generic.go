@@ -0,0 +1,45 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package generic
+
+import (
+	"log/slog"
+)
+
+// New factory to create a new Generic instance.
+func New(
+	logger *slog.Logger,
+) *Generic {
+	return &Generic{
+		logger: logger,
+	}
+}
+
+// ShouldParse never claims a host, the generic provider is only consulted
+// through MatchFallback once every specific provider declined the host.
+func (g *Generic) ShouldParse(_ string) bool {
+	return false
+}
+
+// MatchFallback determine if the generic provider parses the provided host,
+// which is any host at all.
+func (g *Generic) MatchFallback(host string) bool {
+	return host != ""
+}
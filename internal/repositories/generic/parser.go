@@ -0,0 +1,96 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package generic
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/retr0h/git-url-parse/internal/repositories"
+	"github.com/retr0h/git-url-parse/pkg/api"
+	"github.com/retr0h/git-url-parse/pkg/giturl"
+)
+
+const (
+	providerName string = "generic"
+	// repoPath the path of a repo, the owner being the segment preceding the
+	// repo, when any.
+	repoPath string = `(?:[^/]+/)*?(?:(?P<owner>[^/]+)/)?(?P<repo>[^/]+?)(?:\.git)?/?`
+	// revisionMarker the segment Gerrit and Gitiles paths name a change or
+	// revision after, which no repo path contains.
+	revisionMarker string = "/+/"
+)
+
+// patterns follow the transports git recognizes in `url.c`: URLs with a
+// scheme, then scp-like `[user@]host:path`, where a colon precedes any slash.
+var patterns = repositories.MustCompile([]repositories.Pattern{
+	{
+		Schemes: []string{"ssh", "git", "http", "https", "ftp", "ftps", "rsync", "git+ssh", "ssh+git", "git+http", "git+https"},
+		Path:    `^/` + repoPath + `$`,
+	},
+	{
+		Schemes: []string{repositories.SchemeSCPLike},
+		Path:    `^/?` + repoPath + `$`,
+	},
+})
+
+// Parse the provided git URL.
+func (g *Generic) Parse(url string) (*api.Repository, error) {
+	return repositories.Parse(g, url)
+}
+
+// ParseURL parse the tokens of the provided, redacted, git URL.
+func (g *Generic) ParseURL(url string, u *giturl.URL) (*api.Repository, error) {
+	// change and browse pages of undeclared Gerrit and Gitiles hosts are not
+	// repos, and would report `+` as the owner
+	if strings.Contains("/"+u.Path+"/", revisionMarker) {
+		return nil, repositories.NoMatch(url, u, providerName, patterns)
+	}
+
+	for _, pattern := range patterns {
+		mm := pattern.Match(u)
+
+		g.logger.LogAttrs(
+			context.Background(),
+			slog.LevelDebug,
+			"matching url",
+			slog.String("url", url),
+			slog.String("regexp", pattern.Path),
+		)
+
+		if mm != nil {
+			return &api.Repository{
+				Protocol: u.Scheme,
+				Host:     u.Host,
+				Provider: providerName,
+				Resource: u.Host,
+				User:     u.User,
+				Port:     u.Port,
+				Pathname: u.Path,
+				Owner:    mm["owner"],
+				Repo:     mm["repo"],
+				HREF:     url,
+			}, nil
+		}
+	}
+
+	return nil, repositories.NoMatch(url, u, providerName, patterns)
+}
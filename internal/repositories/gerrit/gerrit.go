@@ -0,0 +1,53 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package gerrit
+
+import (
+	"log/slog"
+	"strings"
+)
+
+const (
+	defaultHost      string = "review.opendev.org"
+	googlesourceHost string = "-review.googlesource.com"
+)
+
+// New factory to create a new Gerrit instance.
+func New(
+	logger *slog.Logger,
+) *Gerrit {
+	return &Gerrit{
+		logger: logger,
+	}
+}
+
+// ShouldParse determine if the provided URL belongs to a well known Gerrit
+// instance.  Self-hosted Gerrit instances must be declared with
+// `repository.Registry.RegisterHost`, or matched with MatchHeuristic.
+func (g *Gerrit) ShouldParse(host string) bool {
+	return host == defaultHost || strings.HasSuffix(host, googlesourceHost)
+}
+
+// MatchHeuristic determine if the provided host looks like a self-hosted
+// Gerrit instance, as in `review.example.com` or `gerrit.example.com`.
+func (g *Gerrit) MatchHeuristic(host string) bool {
+	return strings.HasPrefix(host, "review.") || strings.HasPrefix(host, "gerrit.")
+}
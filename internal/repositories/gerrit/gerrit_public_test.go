@@ -0,0 +1,127 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package gerrit_test
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/retr0h/git-url-parse/internal"
+	"github.com/retr0h/git-url-parse/internal/repositories/gerrit"
+)
+
+type GerritPublicTestSuite struct {
+	suite.Suite
+
+	rm internal.ParserManager
+
+	logger *slog.Logger
+}
+
+func (suite *GerritPublicTestSuite) SetupTest() {
+	suite.logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	suite.rm = gerrit.New(suite.logger)
+}
+
+func (suite *GerritPublicTestSuite) TestShouldParse() {
+	type test struct {
+		input string
+		want  bool
+	}
+
+	tests := []test{
+		{
+			input: "review.opendev.org",
+			want:  true,
+		},
+		{
+			input: "go-review.googlesource.com",
+			want:  true,
+		},
+		// failure cases
+		{
+			input: "go.googlesource.com",
+			want:  false,
+		},
+		{
+			input: "example.com",
+			want:  false,
+		},
+		{
+			input: "example",
+			want:  false,
+		},
+		{
+			input: ".com",
+			want:  false,
+		},
+	}
+
+	for _, tc := range tests {
+		got := suite.rm.ShouldParse(tc.input)
+
+		assert.Equal(suite.T(), tc.want, got)
+	}
+}
+
+func (suite *GerritPublicTestSuite) TestMatchHeuristic() {
+	type test struct {
+		input string
+		want  bool
+	}
+
+	tests := []test{
+		{
+			input: "review.example.com",
+			want:  true,
+		},
+		{
+			input: "gerrit.example.com",
+			want:  true,
+		},
+		// failure cases
+		{
+			input: "codereview.example.com",
+			want:  false,
+		},
+		{
+			input: "example.com",
+			want:  false,
+		},
+	}
+
+	for _, tc := range tests {
+		got := gerrit.New(suite.logger).MatchHeuristic(tc.input)
+
+		assert.Equal(suite.T(), tc.want, got)
+	}
+}
+
+// In order for `go test` to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run.
+func TestGerritPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(GerritPublicTestSuite))
+}
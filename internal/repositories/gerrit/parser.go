@@ -0,0 +1,112 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package gerrit
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/retr0h/git-url-parse/internal/repositories"
+	"github.com/retr0h/git-url-parse/pkg/api"
+	"github.com/retr0h/git-url-parse/pkg/giturl"
+)
+
+const (
+	providerName string = "gerrit"
+)
+
+var patterns = repositories.MustCompile([]repositories.Pattern{
+	{
+		Schemes: []string{"http", "https"},
+		Path:    `^/(?:a/)?c/(?P<project>.+?)/\+/(?P<change>\d+)(?:/\d+(?:/(?P<path>.*))?)?/?$`,
+	},
+	{
+		Schemes: []string{"http", "https"},
+		Path:    `^/(?:a/)?plugins/gitiles/(?P<project>.+?)(?:/\+/(?P<revision>.*))?/?$`,
+	},
+	{
+		Schemes: []string{"http", "https"},
+		Path:    `^/(?:a/)?(?P<project>.+?)(?:\.git)?/?$`,
+	},
+	{
+		Schemes: []string{"ssh", "git+ssh", "git"},
+		Path:    `^/(?P<project>.+?)(?:\.git)?/?$`,
+	},
+})
+
+// Parse the provided Gerrit URL.
+func (g *Gerrit) Parse(url string) (*api.Repository, error) {
+	return repositories.Parse(g, url)
+}
+
+// ParseURL parse the tokens of the provided, redacted, Gerrit URL.
+func (g *Gerrit) ParseURL(url string, u *giturl.URL) (*api.Repository, error) {
+	for _, pattern := range patterns {
+		mm := pattern.Match(u)
+
+		g.logger.LogAttrs(
+			context.Background(),
+			slog.LevelDebug,
+			"matching url",
+			slog.String("url", url),
+			slog.String("regexp", pattern.Path),
+		)
+
+		if mm != nil {
+			owner, repo := repositories.SplitProject(mm["project"])
+			ref, path := repositories.SplitGitilesRevision(mm["revision"])
+			if mm["path"] != "" {
+				path = repositories.DecodePath(mm["path"])
+			}
+
+			lineStart, lineEnd := repositories.FileLines(
+				path != "",
+				repositories.LinesNumeric,
+				u.Fragment,
+			)
+
+			return &api.Repository{
+				Protocol:  u.Scheme,
+				Host:      u.Host,
+				Provider:  providerName,
+				Resource:  u.Host,
+				Owner:     owner,
+				Project:   mm["project"],
+				Repo:      repo,
+				Path:      path,
+				LineStart: lineStart,
+				LineEnd:   lineEnd,
+				Branch:    repositories.BranchName(ref),
+				Ref:       ref,
+				Change:    mm["change"],
+				User:      u.User,
+				Port:      u.Port,
+				Pathname:  u.Path,
+				HREF:      url,
+
+				// branches and tags may contain `/`, and run into the path
+				RefAmbiguous: repositories.IsRefAmbiguous(ref, path),
+			}, nil
+		}
+	}
+
+	return nil, repositories.NoMatch(url, u, providerName, patterns)
+}
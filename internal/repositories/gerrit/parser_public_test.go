@@ -0,0 +1,348 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package gerrit_test
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/retr0h/git-url-parse/internal"
+	"github.com/retr0h/git-url-parse/internal/repositories/gerrit"
+	"github.com/retr0h/git-url-parse/pkg"
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+type ParserPublicTestSuite struct {
+	suite.Suite
+
+	rm internal.ParserManager
+
+	logger *slog.Logger
+}
+
+func (suite *ParserPublicTestSuite) SetupTest() {
+	suite.logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	suite.rm = gerrit.New(suite.logger)
+}
+
+func (suite *ParserPublicTestSuite) TestParse() {
+	type repository struct {
+		branch    string
+		change    string
+		href      string
+		owner     string
+		path      string
+		project   string
+		protocol  string
+		protocols []string
+		provider  string
+		ref       api.Ref
+		repo      string
+		resource  string
+	}
+
+	type test struct {
+		input   string
+		want    *repository
+		wantErr bool
+	}
+
+	tests := []test{
+		{
+			input: "ssh://user@review.example.com:29418/owner/repository",
+			want: &repository{
+				protocol:  "ssh",
+				protocols: []string{"ssh"},
+				resource:  "review.example.com",
+				href:      "ssh://user@review.example.com:29418/owner/repository",
+				owner:     "owner",
+				repo:      "repository",
+				project:   "owner/repository",
+				path:      "",
+				branch:    "",
+				ref:       api.Ref{},
+				change:    "",
+				provider:  "gerrit",
+			},
+			wantErr: false,
+		},
+		{
+			input: "ssh://user@review.example.com:29418/owner/sub/repository.git",
+			want: &repository{
+				protocol:  "ssh",
+				protocols: []string{"ssh"},
+				resource:  "review.example.com",
+				href:      "ssh://user@review.example.com:29418/owner/sub/repository.git",
+				owner:     "owner/sub",
+				repo:      "repository",
+				project:   "owner/sub/repository",
+				path:      "",
+				branch:    "",
+				ref:       api.Ref{},
+				change:    "",
+				provider:  "gerrit",
+			},
+			wantErr: false,
+		},
+		{
+			input: "ssh://user@review.example.com:29418/repository",
+			want: &repository{
+				protocol:  "ssh",
+				protocols: []string{"ssh"},
+				resource:  "review.example.com",
+				href:      "ssh://user@review.example.com:29418/repository",
+				owner:     "",
+				repo:      "repository",
+				project:   "repository",
+				path:      "",
+				branch:    "",
+				ref:       api.Ref{},
+				change:    "",
+				provider:  "gerrit",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://review.example.com/owner/repository",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "review.example.com",
+				href:      "https://review.example.com/owner/repository",
+				owner:     "owner",
+				repo:      "repository",
+				project:   "owner/repository",
+				path:      "",
+				branch:    "",
+				ref:       api.Ref{},
+				change:    "",
+				provider:  "gerrit",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://review.example.com/a/owner/repository",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "review.example.com",
+				href:      "https://review.example.com/a/owner/repository",
+				owner:     "owner",
+				repo:      "repository",
+				project:   "owner/repository",
+				path:      "",
+				branch:    "",
+				ref:       api.Ref{},
+				change:    "",
+				provider:  "gerrit",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://review.opendev.org/c/openstack/nova/+/12345",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "review.opendev.org",
+				href:      "https://review.opendev.org/c/openstack/nova/+/12345",
+				owner:     "openstack",
+				repo:      "nova",
+				project:   "openstack/nova",
+				path:      "",
+				branch:    "",
+				ref:       api.Ref{},
+				change:    "12345",
+				provider:  "gerrit",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://review.example.com/c/owner/sub/repository/+/12345/",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "review.example.com",
+				href:      "https://review.example.com/c/owner/sub/repository/+/12345/",
+				owner:     "owner/sub",
+				repo:      "repository",
+				project:   "owner/sub/repository",
+				path:      "",
+				branch:    "",
+				ref:       api.Ref{},
+				change:    "12345",
+				provider:  "gerrit",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://review.example.com/c/owner/repository/+/12345/3/src/main.go",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "review.example.com",
+				href:      "https://review.example.com/c/owner/repository/+/12345/3/src/main.go",
+				owner:     "owner",
+				repo:      "repository",
+				project:   "owner/repository",
+				path:      "src/main.go",
+				branch:    "",
+				ref:       api.Ref{},
+				change:    "12345",
+				provider:  "gerrit",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://review.example.com/plugins/gitiles/owner/repository",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "review.example.com",
+				href:      "https://review.example.com/plugins/gitiles/owner/repository",
+				owner:     "owner",
+				repo:      "repository",
+				project:   "owner/repository",
+				path:      "",
+				branch:    "",
+				ref:       api.Ref{},
+				change:    "",
+				provider:  "gerrit",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://review.example.com/plugins/gitiles/owner/repository/+/refs/heads/master/src/main.go",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "review.example.com",
+				href:      "https://review.example.com/plugins/gitiles/owner/repository/+/refs/heads/master/src/main.go",
+				owner:     "owner",
+				repo:      "repository",
+				project:   "owner/repository",
+				path:      "src/main.go",
+				branch:    "master",
+				ref:       api.Ref{Kind: api.RefKindBranch, Name: "master"},
+				change:    "",
+				provider:  "gerrit",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://review.example.com/a/plugins/gitiles/owner/repository/+/refs/tags/v1.0.0",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "review.example.com",
+				href:      "https://review.example.com/a/plugins/gitiles/owner/repository/+/refs/tags/v1.0.0",
+				owner:     "owner",
+				repo:      "repository",
+				project:   "owner/repository",
+				path:      "",
+				branch:    "",
+				ref:       api.Ref{Kind: api.RefKindTag, Name: "v1.0.0"},
+				change:    "",
+				provider:  "gerrit",
+			},
+			wantErr: false,
+		},
+		// failure cases
+		{
+			input:   "https://review.example.com/",
+			want:    &repository{},
+			wantErr: true,
+		},
+		{
+			input:   "git@review.example.com:owner/repository.git",
+			want:    &repository{},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		var got pkg.RepositoryManager
+		got, err := suite.rm.Parse(tc.input)
+
+		if tc.wantErr {
+			assert.Error(suite.T(), err)
+		} else {
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tc.want.protocol, got.GetProtocol())
+			assert.Equal(suite.T(), tc.want.protocols, got.GetProtocols())
+			assert.Equal(suite.T(), tc.want.resource, got.GetResourceName())
+			assert.Equal(suite.T(), tc.want.href, got.GetHREF())
+			assert.Equal(suite.T(), tc.want.owner, got.GetOwnerName())
+			assert.Equal(suite.T(), tc.want.repo, got.GetRepoName())
+			assert.Equal(suite.T(), tc.want.project, got.GetProjectName())
+			assert.Equal(suite.T(), tc.want.path, got.GetPath())
+			assert.Equal(suite.T(), tc.want.branch, got.GetBranchName())
+			assert.Equal(suite.T(), tc.want.ref, got.GetRef())
+			assert.Equal(suite.T(), tc.want.change, got.GetChangeNumber())
+			assert.Equal(suite.T(), tc.want.provider, got.GetProviderName())
+		}
+	}
+}
+
+func (suite *ParserPublicTestSuite) TestParseRefAmbiguous() {
+	type test struct {
+		input string
+		want  bool
+	}
+
+	tests := []test{
+		{
+			input: "https://review.example.com/plugins/gitiles/owner/repository/+/refs/heads/feature/login/src",
+			want:  true,
+		},
+		{
+			input: "https://review.example.com/plugins/gitiles/owner/repository/+/refs/tags/release/v1.0/x.go",
+			want:  true,
+		},
+		// failure cases
+		{
+			input: "https://review.example.com/plugins/gitiles/owner/repository/+/4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a/src",
+			want:  false,
+		},
+		{
+			input: "https://review.example.com/plugins/gitiles/owner/repository/+/refs/heads/main",
+			want:  false,
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := suite.rm.Parse(tc.input)
+		require.NoError(suite.T(), err, tc.input)
+
+		assert.Equal(suite.T(), tc.want, got.GetRefAmbiguous(), tc.input)
+	}
+}
+
+// In order for `go test` to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run.
+func TestParserPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(ParserPublicTestSuite))
+}
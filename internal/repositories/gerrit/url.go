@@ -0,0 +1,67 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package gerrit
+
+import (
+	"fmt"
+
+	"github.com/retr0h/git-url-parse/internal/repositories"
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+const (
+	defaultSSHPort string = "29418"
+)
+
+// CloneRemote return the parts of the Gerrit repo's clone URL.  SSH is
+// served on port 29418 unless the parsed URL names another, so the scp-like
+// format, which cannot name the port, is not served.
+func (g *Gerrit) CloneRemote(
+	repo *api.Repository,
+	format api.CloneFormat,
+) (api.Remote, error) {
+	remote, err := repo.DefaultRemote(format)
+	if err != nil {
+		return api.Remote{}, err
+	}
+
+	switch format {
+	case api.CloneFormatSCP, api.CloneFormatSSH, api.CloneFormatGitSSH:
+		if remote.Port == "" {
+			remote.Port = defaultSSHPort
+		}
+	}
+
+	return remote, nil
+}
+
+// WebURL return the URL of the Gerrit repo's web page, served by the Gitiles
+// plugin, as in `https://review.opendev.org/plugins/gitiles/zuul/zuul/+/master`.
+func (g *Gerrit) WebURL(
+	repo *api.Repository,
+	view api.View,
+	location api.Location,
+) (string, error) {
+	return fmt.Sprintf("%s/plugins/gitiles/%s/%s",
+		repositories.WebBase(repo, repo.Resource),
+		repo.Project,
+		repositories.GitilesWebPath(view, location),
+	), nil
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package gerrit_test
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/retr0h/git-url-parse/internal/repositories/gerrit"
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+type URLPublicTestSuite struct {
+	suite.Suite
+
+	logger *slog.Logger
+}
+
+func (suite *URLPublicTestSuite) SetupTest() {
+	suite.logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
+}
+
+func (suite *URLPublicTestSuite) TestCloneRemote() {
+	type test struct {
+		input   api.CloneFormat
+		want    api.Remote
+		wantErr bool
+	}
+
+	repo := &api.Repository{
+		Protocol: "https",
+		Resource: "review.opendev.org",
+		Owner:    "openstack",
+		Repo:     "nova",
+		HREF:     "https://review.opendev.org/openstack/nova",
+	}
+
+	tests := []test{
+		{
+			input:   api.CloneFormatSSH,
+			want:    api.Remote{User: "git", Host: "review.opendev.org", Port: "29418", Path: "openstack/nova.git"},
+			wantErr: false,
+		},
+		{
+			input:   api.CloneFormatHTTPS,
+			want:    api.Remote{Host: "review.opendev.org", Path: "openstack/nova.git"},
+			wantErr: false,
+		},
+		{
+			input:   api.CloneFormatGit,
+			want:    api.Remote{Host: "review.opendev.org", Path: "openstack/nova.git"},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := gerrit.New(suite.logger).CloneRemote(repo, tc.input)
+
+		if tc.wantErr {
+			assert.Error(suite.T(), err)
+		} else {
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tc.want, got)
+		}
+	}
+}
+
+func (suite *URLPublicTestSuite) TestWebURL() {
+	type input struct {
+		view     api.View
+		location api.Location
+	}
+
+	type test struct {
+		input   *input
+		want    string
+		wantErr bool
+	}
+
+	repo := &api.Repository{
+		Protocol: "ssh",
+		Resource: "review.opendev.org",
+		Port:     "29418",
+		Project:  "zuul/zuul",
+	}
+
+	tests := []test{
+		{
+			input:   &input{view: api.ViewTree, location: api.Location{Ref: "master"}},
+			want:    "https://review.opendev.org/plugins/gitiles/zuul/zuul/+/master",
+			wantErr: false,
+		},
+		{
+			input:   &input{view: api.ViewBlame, location: api.Location{Ref: "master", Path: "README.rst"}},
+			want:    "https://review.opendev.org/plugins/gitiles/zuul/zuul/+blame/master/README.rst",
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := gerrit.New(suite.logger).WebURL(repo, tc.input.view, tc.input.location)
+
+		if tc.wantErr {
+			assert.Error(suite.T(), err)
+		} else {
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tc.want, got)
+		}
+	}
+}
+
+// In order for `go test` to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run.
+func TestURLPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(URLPublicTestSuite))
+}
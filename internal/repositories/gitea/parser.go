@@ -0,0 +1,108 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package gitea
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/retr0h/git-url-parse/internal/repositories"
+	"github.com/retr0h/git-url-parse/pkg/api"
+	"github.com/retr0h/git-url-parse/pkg/giturl"
+)
+
+const (
+	providerName string = "gitea"
+)
+
+var patterns = repositories.MustCompile([]repositories.Pattern{
+	{
+		Schemes: []string{"http", "https"},
+		Path:    `^/(?P<owner>[^/]+)/(?P<repo>[^/]+)/(?:src|raw)/(?P<kind>branch|tag|commit)/(?P<branch>[^/]+)(?:/(?P<path>.*))?$`,
+	},
+	{
+		Schemes: []string{"http", "https"},
+		Path:    `^/(?P<owner>[^/]+)/(?P<repo>[^/]+?)(?:\.git)?/?$`,
+	},
+	{
+		Schemes: []string{"ssh", "git+ssh", "git"},
+		Path:    `^/(?P<owner>[^/]+)/(?P<repo>[^/]+?)(?:\.git)?$`,
+	},
+	{
+		Schemes: []string{repositories.SchemeSCPLike},
+		Path:    `^(?P<owner>[^/]+)/(?P<repo>[^/]+?)(?:\.git)?$`,
+	},
+})
+
+// Parse the provided Gitea URL.
+func (g *Gitea) Parse(url string) (*api.Repository, error) {
+	return repositories.Parse(g, url)
+}
+
+// ParseURL parse the tokens of the provided, redacted, Gitea URL.
+func (g *Gitea) ParseURL(url string, u *giturl.URL) (*api.Repository, error) {
+	for _, pattern := range patterns {
+		mm := pattern.Match(u)
+
+		g.logger.LogAttrs(
+			context.Background(),
+			slog.LevelDebug,
+			"matching url",
+			slog.String("url", url),
+			slog.String("regexp", pattern.Path),
+		)
+
+		if mm != nil {
+			ref := api.Ref{Kind: api.RefKind(mm["kind"]), Name: mm["branch"]}
+			path := repositories.DecodePath(strings.TrimSuffix(mm["path"], "/"))
+
+			lineStart, lineEnd := repositories.FileLines(
+				path != "",
+				repositories.LinesGitHub,
+				u.Fragment,
+			)
+
+			return &api.Repository{
+				Protocol:  repositories.Protocol(u),
+				Host:      u.Host,
+				Provider:  providerName,
+				Resource:  u.Host,
+				Owner:     mm["owner"],
+				Repo:      mm["repo"],
+				Path:      path,
+				LineStart: lineStart,
+				LineEnd:   lineEnd,
+				Branch:    repositories.BranchName(ref),
+				Ref:       ref,
+				User:      u.User,
+				Port:      u.Port,
+				Pathname:  u.Path,
+				HREF:      url,
+
+				// branches and tags may contain `/`, and run into the path
+				RefAmbiguous: repositories.IsRefAmbiguous(ref, path),
+			}, nil
+		}
+	}
+
+	return nil, repositories.NoMatch(url, u, providerName, patterns)
+}
@@ -0,0 +1,277 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package gitea_test
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/retr0h/git-url-parse/internal"
+	"github.com/retr0h/git-url-parse/internal/repositories/gitea"
+	"github.com/retr0h/git-url-parse/pkg"
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+type ParserPublicTestSuite struct {
+	suite.Suite
+
+	rm internal.ParserManager
+
+	logger *slog.Logger
+}
+
+func (suite *ParserPublicTestSuite) SetupTest() {
+	suite.logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	suite.rm = gitea.New(suite.logger)
+}
+
+func (suite *ParserPublicTestSuite) TestParse() {
+	type repository struct {
+		branch    string
+		href      string
+		owner     string
+		path      string
+		protocol  string
+		protocols []string
+		provider  string
+		ref       api.Ref
+		repo      string
+		resource  string
+	}
+
+	type test struct {
+		input   string
+		want    *repository
+		wantErr bool
+	}
+
+	tests := []test{
+		{
+			input: "https://codeberg.org/owner/repository",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "codeberg.org",
+				href:      "https://codeberg.org/owner/repository",
+				owner:     "owner",
+				repo:      "repository",
+				path:      "",
+				branch:    "",
+				ref:       api.Ref{},
+				provider:  "gitea",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://codeberg.org/owner/repository.git",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "codeberg.org",
+				href:      "https://codeberg.org/owner/repository.git",
+				owner:     "owner",
+				repo:      "repository",
+				path:      "",
+				branch:    "",
+				ref:       api.Ref{},
+				provider:  "gitea",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://codeberg.org/owner/repository/src/branch/main/docs/README.md",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "codeberg.org",
+				href:      "https://codeberg.org/owner/repository/src/branch/main/docs/README.md",
+				owner:     "owner",
+				repo:      "repository",
+				path:      "docs/README.md",
+				branch:    "main",
+				ref:       api.Ref{Kind: api.RefKindBranch, Name: "main"},
+				provider:  "gitea",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://codeberg.org/owner/repository/src/branch/main",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "codeberg.org",
+				href:      "https://codeberg.org/owner/repository/src/branch/main",
+				owner:     "owner",
+				repo:      "repository",
+				path:      "",
+				branch:    "main",
+				ref:       api.Ref{Kind: api.RefKindBranch, Name: "main"},
+				provider:  "gitea",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://codeberg.org/owner/repository/src/tag/v1.2.3/go.mod",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "codeberg.org",
+				href:      "https://codeberg.org/owner/repository/src/tag/v1.2.3/go.mod",
+				owner:     "owner",
+				repo:      "repository",
+				path:      "go.mod",
+				branch:    "",
+				ref:       api.Ref{Kind: api.RefKindTag, Name: "v1.2.3"},
+				provider:  "gitea",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://codeberg.org/owner/repository/src/commit/4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a/go.mod",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "codeberg.org",
+				href:      "https://codeberg.org/owner/repository/src/commit/4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a/go.mod",
+				owner:     "owner",
+				repo:      "repository",
+				path:      "go.mod",
+				branch:    "",
+				ref:       api.Ref{Kind: api.RefKindCommit, Name: "4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a"},
+				provider:  "gitea",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://codeberg.org/owner/repository/raw/branch/main/docs/README.md",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "codeberg.org",
+				href:      "https://codeberg.org/owner/repository/raw/branch/main/docs/README.md",
+				owner:     "owner",
+				repo:      "repository",
+				path:      "docs/README.md",
+				branch:    "main",
+				ref:       api.Ref{Kind: api.RefKindBranch, Name: "main"},
+				provider:  "gitea",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://git.example.com:3000/owner/repository/raw/tag/v1.2.3/docs/",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "git.example.com",
+				href:      "https://git.example.com:3000/owner/repository/raw/tag/v1.2.3/docs/",
+				owner:     "owner",
+				repo:      "repository",
+				path:      "docs",
+				branch:    "",
+				ref:       api.Ref{Kind: api.RefKindTag, Name: "v1.2.3"},
+				provider:  "gitea",
+			},
+			wantErr: false,
+		},
+		{
+			input: "git@codeberg.org:owner/repository.git",
+			want: &repository{
+				protocol:  "git",
+				protocols: []string{"git"},
+				resource:  "codeberg.org",
+				href:      "git@codeberg.org:owner/repository.git",
+				owner:     "owner",
+				repo:      "repository",
+				path:      "",
+				branch:    "",
+				ref:       api.Ref{},
+				provider:  "gitea",
+			},
+			wantErr: false,
+		},
+		{
+			input: "ssh://git@git.example.com:2222/owner/repository.git",
+			want: &repository{
+				protocol:  "ssh",
+				protocols: []string{"ssh"},
+				resource:  "git.example.com",
+				href:      "ssh://git@git.example.com:2222/owner/repository.git",
+				owner:     "owner",
+				repo:      "repository",
+				path:      "",
+				branch:    "",
+				ref:       api.Ref{},
+				provider:  "gitea",
+			},
+			wantErr: false,
+		},
+		// failure cases
+		{
+			input:   "https://codeberg.org/",
+			want:    &repository{},
+			wantErr: true,
+		},
+		{
+			input:   "https://codeberg.org/owner/repository/src/main/README.md",
+			want:    &repository{},
+			wantErr: true,
+		},
+		{
+			input:   "git@codeberg.org:foobar/owner/repository.git",
+			want:    &repository{},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		var got pkg.RepositoryManager
+		got, err := suite.rm.Parse(tc.input)
+
+		if tc.wantErr {
+			assert.Error(suite.T(), err)
+		} else {
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tc.want.protocol, got.GetProtocol())
+			assert.Equal(suite.T(), tc.want.protocols, got.GetProtocols())
+			assert.Equal(suite.T(), tc.want.resource, got.GetResourceName())
+			assert.Equal(suite.T(), tc.want.href, got.GetHREF())
+			assert.Equal(suite.T(), tc.want.owner, got.GetOwnerName())
+			assert.Equal(suite.T(), tc.want.repo, got.GetRepoName())
+			assert.Equal(suite.T(), tc.want.path, got.GetPath())
+			assert.Equal(suite.T(), tc.want.branch, got.GetBranchName())
+			assert.Equal(suite.T(), tc.want.ref, got.GetRef())
+			assert.Equal(suite.T(), tc.want.provider, got.GetProviderName())
+		}
+	}
+}
+
+// In order for `go test` to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run.
+func TestParserPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(ParserPublicTestSuite))
+}
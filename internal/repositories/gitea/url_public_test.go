@@ -0,0 +1,166 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package gitea_test
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/retr0h/git-url-parse/internal/repositories/gitea"
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+type URLPublicTestSuite struct {
+	suite.Suite
+
+	logger *slog.Logger
+}
+
+func (suite *URLPublicTestSuite) SetupTest() {
+	suite.logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
+}
+
+func (suite *URLPublicTestSuite) TestCloneRemote() {
+	type test struct {
+		repo    *api.Repository
+		input   api.CloneFormat
+		want    api.Remote
+		wantErr bool
+	}
+
+	tests := []test{
+		{
+			repo:    &api.Repository{Resource: "codeberg.org", Owner: "owner", Repo: "repo", HREF: "https://codeberg.org/owner/repo"},
+			input:   api.CloneFormatHTTPS,
+			want:    api.Remote{Host: "codeberg.org", Path: "owner/repo.git"},
+			wantErr: false,
+		},
+		// failure cases
+		{
+			repo:    &api.Repository{Resource: "codeberg.org", Owner: "owner", Repo: "repo", HREF: "https://codeberg.org/owner/repo"},
+			input:   api.CloneFormatGit,
+			want:    api.Remote{},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := gitea.New(suite.logger).CloneRemote(tc.repo, tc.input)
+
+		if tc.wantErr {
+			assert.Error(suite.T(), err)
+		} else {
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tc.want, got)
+		}
+	}
+}
+
+func (suite *URLPublicTestSuite) TestWebURL() {
+	type input struct {
+		view     api.View
+		location api.Location
+	}
+
+	type test struct {
+		input   *input
+		want    string
+		wantErr bool
+	}
+
+	repo := &api.Repository{
+		Protocol: "https",
+		Resource: "codeberg.org",
+		Owner:    "owner",
+		Repo:     "repo",
+	}
+
+	tests := []test{
+		{
+			input:   &input{view: api.ViewTree, location: api.Location{Ref: "main"}},
+			want:    "https://codeberg.org/owner/repo/src/branch/main",
+			wantErr: false,
+		},
+		{
+			input:   &input{view: api.ViewBlob, location: api.Location{Ref: "4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a", Path: "README.md"}},
+			want:    "https://codeberg.org/owner/repo/src/commit/4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a/README.md",
+			wantErr: false,
+		},
+		{
+			input:   &input{view: api.ViewBlob, location: api.Location{Ref: "v1.0", RefKind: api.RefKindTag, Path: "README.md"}},
+			want:    "https://codeberg.org/owner/repo/src/tag/v1.0/README.md",
+			wantErr: false,
+		},
+		{
+			input:   &input{view: api.ViewRaw, location: api.Location{Ref: "main", Path: "README.md"}},
+			want:    "https://codeberg.org/owner/repo/raw/branch/main/README.md",
+			wantErr: false,
+		},
+		{
+			input:   &input{view: api.ViewBlame, location: api.Location{Ref: "main", Path: "README.md"}},
+			want:    "https://codeberg.org/owner/repo/blame/branch/main/README.md",
+			wantErr: false,
+		},
+		{
+			input:   &input{view: api.ViewHistory, location: api.Location{Ref: "main", Path: "README.md"}},
+			want:    "https://codeberg.org/owner/repo/commits/branch/main/README.md",
+			wantErr: false,
+		},
+		{
+			input:   &input{view: api.ViewCommit, location: api.Location{Ref: "4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a"}},
+			want:    "https://codeberg.org/owner/repo/commit/4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a",
+			wantErr: false,
+		},
+		{
+			input:   &input{view: api.ViewBlob, location: api.Location{Ref: "main", Path: "x.go", LineStart: 10, LineEnd: 20}},
+			want:    "https://codeberg.org/owner/repo/src/branch/main/x.go#L10-L20",
+			wantErr: false,
+		},
+		// failure cases
+		{
+			input:   &input{view: api.View("unknown"), location: api.Location{Ref: "main"}},
+			want:    "",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := gitea.New(suite.logger).WebURL(repo, tc.input.view, tc.input.location)
+
+		if tc.wantErr {
+			assert.Error(suite.T(), err)
+		} else {
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tc.want, got)
+		}
+	}
+}
+
+// In order for `go test` to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run.
+func TestURLPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(URLPublicTestSuite))
+}
@@ -22,12 +22,14 @@ package github
 
 import (
 	"log/slog"
+	"strings"
 )
 
 const (
 	defaultHost string = "github.com"
 	rawHost     string = "raw.githubusercontent.com"
 	wwwHost     string = "www.github.com"
+	tenantHost  string = ".ghe.com"
 )
 
 // New factory to create a new GitHub instance.
@@ -39,7 +41,10 @@ func New(
 	}
 }
 
-// ShouldParse determine if the provided URL belongs to GitHub.
+// ShouldParse determine if the provided URL belongs to GitHub, including
+// GHE.com data residency tenants.  GitHub Enterprise Server instances must be
+// declared with `repository.Registry.RegisterHost`.
 func (gh *GitHub) ShouldParse(host string) bool {
-	return host == defaultHost || host == rawHost || host == wwwHost
+	return host == defaultHost || host == rawHost || host == wwwHost ||
+		strings.HasSuffix(host, tenantHost)
 }
@@ -65,7 +65,19 @@ func (suite *GitHubPublicTestSuite) TestShouldParse() {
 			input: "www.github.com",
 			want:  true,
 		},
+		{
+			input: "octocorp.ghe.com",
+			want:  true,
+		},
 		// failure cases
+		{
+			input: "github.mycorp.net",
+			want:  false,
+		},
+		{
+			input: "ghe.com.example.com",
+			want:  false,
+		},
 		{
 			input: "example.com",
 			want:  false,
@@ -21,52 +21,117 @@
 package github
 
 import (
-	"fmt"
+	"context"
 	"log/slog"
-	"regexp"
 
 	"github.com/retr0h/git-url-parse/internal/repositories"
 	"github.com/retr0h/git-url-parse/pkg/api"
+	"github.com/retr0h/git-url-parse/pkg/giturl"
 )
 
 const (
 	providerName string = "github"
 )
 
+var (
+	webSchemes = []string{"https"}
+	sshSchemes = []string{"ssh", "git+ssh", "git"}
+	scpSchemes = []string{repositories.SchemeSCPLike}
+)
+
 // ChatGPT-4 generated regexp
-var patterns = []string{
-	`^(?P<scheme>https)://(?P<resource>[^/]+)/(?P<owner>[^/]+)/(?P<repo>[^/]+?)(?:\.git)?(/(?:tree|blob)/(?P<branch>[^/]+)/(?P<path>.*)?)?$`,
-	`^(?P<scheme>https)://(?P<resource>raw\.githubusercontent\.com)/(?P<owner>[^/]+)/(?P<repo>[^/]+)/(?P<branch>[^/]+)/(?P<path>.*)$`,
-	`^(?P<scheme>git)@(?P<resource>github\.com):(?P<owner>[^/]+)/(?P<repo>[^/]+)\.git$`,
+var patterns = repositories.MustCompile([]repositories.Pattern{
+	{Schemes: webSchemes, Path: `^/(?P<owner>[^/]+)/(?P<repo>[^/]+?)(?:\.git)?(/(?P<kind>tree|blob|blame|commits)/(?P<branch>[^/]+)(?:/(?P<path>.*))?)?$`},
+	{Schemes: webSchemes, Path: `^/(?P<owner>[^/]+)/(?P<repo>[^/]+)/(?P<kind>pulls?|issues)(?:/(?P<number>\d+)(?:/.*)?)?$`},
+	{Schemes: webSchemes, Path: `^/(?P<owner>[^/]+)/(?P<repo>[^/]+)/(?P<kind>commit)/(?P<commit>[0-9a-fA-F]+)(?:/.*)?$`},
+	{Schemes: webSchemes, Path: `^/(?P<owner>[^/]+)/(?P<repo>[^/]+)/(?P<kind>compare)/(?P<compare>.+)$`},
+	{Schemes: webSchemes, Path: `^/(?P<owner>[^/]+)/(?P<repo>[^/]+)/(?P<kind>releases)(?:/tag/(?P<tag>.+))?$`},
+	{Schemes: webSchemes, Path: `^/(?P<owner>[^/]+)/(?P<repo>[^/]+)/(?P<kind>tags)$`},
+	{Schemes: webSchemes, Path: `^/(?P<owner>[^/]+)/(?P<repo>[^/]+)/actions/(?P<kind>runs)/(?P<number>\d+)(?:/.*)?$`},
+	{Schemes: webSchemes, Path: `^/(?P<owner>[^/]+)/(?P<repo>[^/]+)/(?P<kind>wiki)(?:/(?P<path>.+))?$`},
+	{Schemes: webSchemes, Host: `^(?P<kind>raw)\.`, Path: `^/(?P<owner>[^/]+)/(?P<repo>[^/]+)/(?P<branch>[^/]+)/(?P<path>.*)$`},
+	{Schemes: webSchemes, Path: `^/(?P<owner>[^/]+)/(?P<repo>[^/]+)/(?P<kind>raw)/(?P<branch>[^/]+)/(?P<path>.*)$`},
+	{Schemes: webSchemes, Path: `^/(?P<kind>raw)/(?P<owner>[^/]+)/(?P<repo>[^/]+)/(?P<branch>[^/]+)/(?P<path>.*)$`},
+	{Schemes: sshSchemes, Path: `^/(?P<owner>[^/]+)/(?P<repo>[^/]+?)(?:\.git)?$`},
+	{Schemes: scpSchemes, Path: `^(?P<owner>[^/]+)/(?P<repo>[^/]+)\.git$`},
+})
+
+// kinds the kind of page named by each pattern's `kind`, clone URLs name
+// none.
+var kinds = map[string]api.Kind{
+	"":         api.KindRepo,
+	"tree":     api.KindTree,
+	"blob":     api.KindBlob,
+	"raw":      api.KindRaw,
+	"blame":    api.KindBlame,
+	"commits":  api.KindHistory,
+	"commit":   api.KindCommit,
+	"pull":     api.KindPullRequest,
+	"pulls":    api.KindPullRequest,
+	"issues":   api.KindIssue,
+	"compare":  api.KindCompare,
+	"releases": api.KindRelease,
+	"tags":     api.KindTag,
+	"runs":     api.KindRun,
+	"wiki":     api.KindWiki,
 }
 
 // Parse the provided GitHub URL.
 func (gh *GitHub) Parse(url string) (*api.Repository, error) {
+	return repositories.Parse(gh, url)
+}
+
+// ParseURL parse the tokens of the provided, redacted, GitHub URL.
+func (gh *GitHub) ParseURL(url string, u *giturl.URL) (*api.Repository, error) {
 	for _, pattern := range patterns {
-		re := regexp.MustCompile(pattern)
-		matches := re.FindStringSubmatch(url)
-		mm := repositories.MakeMatchMap(re, matches)
+		mm := pattern.Match(u)
 
-		gh.logger.Debug(
+		gh.logger.LogAttrs(
+			context.Background(),
+			slog.LevelDebug,
 			"matching url",
 			slog.String("url", url),
-			slog.String("regexp", pattern),
+			slog.String("regexp", pattern.Path),
 		)
 
-		if matches != nil {
+		if mm != nil {
+			branch, ref, path := repositories.MatchRef(mm)
+			base, head := repositories.SplitCompare(mm["compare"])
+
+			kind := kinds[mm["kind"]]
+			lineStart, lineEnd := repositories.FileLines(
+				repositories.IsFileKind(kind),
+				repositories.LinesGitHub,
+				u.Fragment,
+			)
+
 			return &api.Repository{
-				Protocol: mm["scheme"],
-				Host:     mm["resource"],
-				Provider: providerName,
-				Resource: mm["resource"],
-				Owner:    mm["owner"],
-				Repo:     mm["repo"],
-				Path:     mm["path"],
-				Branch:   mm["branch"],
-				HREF:     url,
+				Protocol:  repositories.Protocol(u),
+				Host:      u.Host,
+				Provider:  providerName,
+				Resource:  u.Host,
+				Owner:     mm["owner"],
+				Repo:      mm["repo"],
+				Path:      path,
+				LineStart: lineStart,
+				LineEnd:   lineEnd,
+				Branch:    branch,
+				Kind:      kind,
+				Number:    mm["number"],
+				Commit:    mm["commit"],
+				Base:      base,
+				Head:      head,
+				Ref:       ref,
+				User:      u.User,
+				Port:      u.Port,
+				Pathname:  u.Path,
+				HREF:      url,
+
+				// branches may contain `/`, and run into the path
+				RefAmbiguous: repositories.IsRefAmbiguous(ref, path),
 			}, nil
 		}
 	}
 
-	return nil, fmt.Errorf("could match url: %s to any pattern", url)
+	return nil, repositories.NoMatch(url, u, providerName, patterns)
 }
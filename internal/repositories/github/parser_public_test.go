@@ -32,6 +32,7 @@ import (
 	"github.com/retr0h/git-url-parse/internal"
 	"github.com/retr0h/git-url-parse/internal/repositories/github"
 	"github.com/retr0h/git-url-parse/pkg"
+	"github.com/retr0h/git-url-parse/pkg/api"
 )
 
 type ParserPublicTestSuite struct {
@@ -191,6 +192,21 @@ func (suite *ParserPublicTestSuite) TestParse() {
 			},
 			wantErr: false,
 		},
+		{
+			input: "ssh://git@github.com:22/owner/repository.git",
+			want: &repository{
+				protocol:  "ssh",
+				protocols: []string{"ssh"},
+				resource:  "github.com",
+				owner:     "owner",
+				repo:      "repository",
+				path:      "",
+				branch:    "",
+				provider:  "github",
+				href:      "ssh://git@github.com:22/owner/repository.git",
+			},
+			wantErr: false,
+		},
 		{
 			input: "git@github.com:owner/repository.git",
 			want: &repository{
@@ -206,6 +222,98 @@ func (suite *ParserPublicTestSuite) TestParse() {
 			},
 			wantErr: false,
 		},
+		{
+			input: "https://github.com/owner/repository/raw/main/files/file0.json",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "github.com",
+				owner:     "owner",
+				repo:      "repository",
+				path:      "files/file0.json",
+				branch:    "main",
+				provider:  "github",
+				href:      "https://github.com/owner/repository/raw/main/files/file0.json",
+			},
+			wantErr: false,
+		},
+		// GitHub Enterprise Server
+		{
+			input: "https://github.mycorp.net/owner/repository/blob/main/files/file0.json",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "github.mycorp.net",
+				owner:     "owner",
+				repo:      "repository",
+				path:      "files/file0.json",
+				branch:    "main",
+				provider:  "github",
+				href:      "https://github.mycorp.net/owner/repository/blob/main/files/file0.json",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://raw.github.mycorp.net/owner/repository/main/files/file0.json",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "raw.github.mycorp.net",
+				owner:     "owner",
+				repo:      "repository",
+				path:      "files/file0.json",
+				branch:    "main",
+				provider:  "github",
+				href:      "https://raw.github.mycorp.net/owner/repository/main/files/file0.json",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://github.mycorp.net/raw/owner/repository/main/files/file0.json",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "github.mycorp.net",
+				owner:     "owner",
+				repo:      "repository",
+				path:      "files/file0.json",
+				branch:    "main",
+				provider:  "github",
+				href:      "https://github.mycorp.net/raw/owner/repository/main/files/file0.json",
+			},
+			wantErr: false,
+		},
+		{
+			input: "git@github.mycorp.net:owner/repository.git",
+			want: &repository{
+				protocol:  "git",
+				protocols: []string{"git"},
+				resource:  "github.mycorp.net",
+				owner:     "owner",
+				repo:      "repository",
+				path:      "",
+				branch:    "",
+				provider:  "github",
+				href:      "git@github.mycorp.net:owner/repository.git",
+			},
+			wantErr: false,
+		},
+		// GHE.com data residency
+		{
+			input: "https://octocorp.ghe.com/owner/repository",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "octocorp.ghe.com",
+				owner:     "owner",
+				repo:      "repository",
+				path:      "",
+				branch:    "",
+				provider:  "github",
+				href:      "https://octocorp.ghe.com/owner/repository",
+			},
+			wantErr: false,
+		},
 		// failure cases
 		{
 			input:   "https://github.com/",
@@ -245,6 +353,135 @@ func (suite *ParserPublicTestSuite) TestParse() {
 	}
 }
 
+func (suite *ParserPublicTestSuite) TestParseKind() {
+	type test struct {
+		input  string
+		kind   api.Kind
+		number string
+		commit string
+		base   string
+		head   string
+		tag    string
+		branch string
+		path   string
+	}
+
+	tests := []test{
+		{
+			input: "git@github.com:o/r.git",
+			kind:  api.KindRepo,
+		},
+		{
+			input: "https://github.com/o/r",
+			kind:  api.KindRepo,
+		},
+		{
+			input:  "https://github.com/o/r/tree/main",
+			kind:   api.KindTree,
+			branch: "main",
+		},
+		{
+			input:  "https://github.com/o/r/blob/main/x.go",
+			kind:   api.KindBlob,
+			branch: "main",
+			path:   "x.go",
+		},
+		{
+			input:  "https://github.com/o/r/blame/main/x.go",
+			kind:   api.KindBlame,
+			branch: "main",
+			path:   "x.go",
+		},
+		{
+			input:  "https://github.com/o/r/commits/main/x.go",
+			kind:   api.KindHistory,
+			branch: "main",
+			path:   "x.go",
+		},
+		{
+			input:  "https://raw.githubusercontent.com/o/r/main/x.go",
+			kind:   api.KindRaw,
+			branch: "main",
+			path:   "x.go",
+		},
+		{
+			input:  "https://github.com/o/r/pull/12",
+			kind:   api.KindPullRequest,
+			number: "12",
+		},
+		{
+			input:  "https://github.com/o/r/pull/12/files",
+			kind:   api.KindPullRequest,
+			number: "12",
+		},
+		{
+			input: "https://github.com/o/r/pulls",
+			kind:  api.KindPullRequest,
+		},
+		{
+			input:  "https://github.com/o/r/issues/34",
+			kind:   api.KindIssue,
+			number: "34",
+		},
+		{
+			input:  "https://github.com/o/r/commit/4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a",
+			kind:   api.KindCommit,
+			commit: "4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a",
+		},
+		{
+			input: "https://github.com/o/r/compare/main...feature/x",
+			kind:  api.KindCompare,
+			base:  "main",
+			head:  "feature/x",
+		},
+		{
+			input: "https://github.com/o/r/compare/v1.0..v2.0",
+			kind:  api.KindCompare,
+			base:  "v1.0",
+			head:  "v2.0",
+		},
+		{
+			input: "https://github.com/o/r/releases/tag/v1.0.0",
+			kind:  api.KindRelease,
+			tag:   "v1.0.0",
+		},
+		{
+			input: "https://github.com/o/r/releases",
+			kind:  api.KindRelease,
+		},
+		{
+			input: "https://github.com/o/r/tags",
+			kind:  api.KindTag,
+		},
+		{
+			input:  "https://github.com/o/r/actions/runs/1234567890/job/42",
+			kind:   api.KindRun,
+			number: "1234567890",
+		},
+		{
+			input: "https://github.com/o/r/wiki/Home",
+			kind:  api.KindWiki,
+			path:  "Home",
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := suite.rm.Parse(tc.input)
+		require.NoError(suite.T(), err, tc.input)
+
+		assert.Equal(suite.T(), tc.kind, got.GetKind(), tc.input)
+		assert.Equal(suite.T(), tc.number, got.GetNumber(), tc.input)
+		assert.Equal(suite.T(), tc.commit, got.GetCommit(), tc.input)
+		assert.Equal(suite.T(), tc.base, got.GetBase(), tc.input)
+		assert.Equal(suite.T(), tc.head, got.GetHead(), tc.input)
+		if tc.tag != "" {
+			assert.Equal(suite.T(), api.Ref{Kind: api.RefKindTag, Name: tc.tag}, got.GetRef(), tc.input)
+		}
+		assert.Equal(suite.T(), tc.branch, got.GetBranchName(), tc.input)
+		assert.Equal(suite.T(), tc.path, got.GetPath(), tc.input)
+	}
+}
+
 // In order for `go test` to run this suite, we need to create
 // a normal test function and pass our suite to suite.Run.
 func TestParserPublicTestSuite(t *testing.T) {
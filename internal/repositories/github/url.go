@@ -0,0 +1,103 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package github
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/retr0h/git-url-parse/internal/repositories"
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+// views the path segment of each web page.
+var views = map[api.View]string{
+	api.ViewTree:    "tree",
+	api.ViewBlob:    "blob",
+	api.ViewRaw:     "raw",
+	api.ViewBlame:   "blame",
+	api.ViewHistory: "commits",
+	api.ViewCommit:  "commit",
+}
+
+// CloneRemote return the parts of the GitHub repo's clone URL, on the host
+// serving its web pages, so repos parsed from raw file or `www.` URLs clone
+// from `github.com`.  The git protocol is not served.
+func (gh *GitHub) CloneRemote(
+	repo *api.Repository,
+	format api.CloneFormat,
+) (api.Remote, error) {
+	if format == api.CloneFormatGit {
+		return api.Remote{}, fmt.Errorf("clone format: %s not supported by provider: %s", format, providerName)
+	}
+
+	remote, err := repo.DefaultRemote(format)
+	if err != nil {
+		return api.Remote{}, err
+	}
+	remote.Host = webHost(remote.Host)
+
+	return remote, nil
+}
+
+// WebURL return the URL of the GitHub repo's web page, anchoring lines as in
+// `#L10-L20`.  Raw files on github.com are served from
+// `raw.githubusercontent.com`.
+func (gh *GitHub) WebURL(
+	repo *api.Repository,
+	view api.View,
+	location api.Location,
+) (string, error) {
+	segment, ok := views[view]
+	if !ok {
+		return "", fmt.Errorf("view: %s not supported by provider: %s", view, providerName)
+	}
+
+	host := webHost(repo.Resource)
+	revision := location.Ref + "/" + location.Path
+	if view == api.ViewCommit {
+		revision = location.Ref
+	}
+
+	if view == api.ViewRaw && host == defaultHost {
+		return fmt.Sprintf("https://%s/%s/%s/%s",
+			rawHost, repo.Owner, repo.Repo, repositories.EscapePath(revision)), nil
+	}
+
+	return fmt.Sprintf("%s/%s/%s/%s/%s%s",
+		repositories.WebBase(repo, host),
+		repo.Owner,
+		repo.Repo,
+		segment,
+		repositories.EscapePath(revision),
+		repositories.LineAnchor(view, location, "#L%d", "#L%d-L%d"),
+	), nil
+}
+
+// webHost return the host serving the repo's web pages, for repos parsed from
+// raw file URLs.
+func webHost(resource string) string {
+	if resource == rawHost || resource == wwwHost {
+		return defaultHost
+	}
+
+	return strings.TrimPrefix(resource, "raw.")
+}
@@ -0,0 +1,301 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package gitiles_test
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/retr0h/git-url-parse/internal"
+	"github.com/retr0h/git-url-parse/internal/repositories/gitiles"
+	"github.com/retr0h/git-url-parse/pkg"
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+type ParserPublicTestSuite struct {
+	suite.Suite
+
+	rm internal.ParserManager
+
+	logger *slog.Logger
+}
+
+func (suite *ParserPublicTestSuite) SetupTest() {
+	suite.logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	suite.rm = gitiles.New(suite.logger)
+}
+
+func (suite *ParserPublicTestSuite) TestParse() {
+	type repository struct {
+		branch    string
+		href      string
+		owner     string
+		path      string
+		project   string
+		protocol  string
+		protocols []string
+		provider  string
+		ref       api.Ref
+		repo      string
+		resource  string
+	}
+
+	type test struct {
+		input   string
+		want    *repository
+		wantErr bool
+	}
+
+	tests := []test{
+		{
+			input: "https://go.googlesource.com/go",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "go.googlesource.com",
+				href:      "https://go.googlesource.com/go",
+				owner:     "",
+				repo:      "go",
+				project:   "go",
+				path:      "",
+				branch:    "",
+				ref:       api.Ref{},
+				provider:  "gitiles",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://go.googlesource.com/go.git",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "go.googlesource.com",
+				href:      "https://go.googlesource.com/go.git",
+				owner:     "",
+				repo:      "go",
+				project:   "go",
+				path:      "",
+				branch:    "",
+				ref:       api.Ref{},
+				provider:  "gitiles",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://go.googlesource.com/a/go",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "go.googlesource.com",
+				href:      "https://go.googlesource.com/a/go",
+				owner:     "",
+				repo:      "go",
+				project:   "go",
+				path:      "",
+				branch:    "",
+				ref:       api.Ref{},
+				provider:  "gitiles",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://go.googlesource.com/go/+/refs/heads/master/src/net/url/url.go",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "go.googlesource.com",
+				href:      "https://go.googlesource.com/go/+/refs/heads/master/src/net/url/url.go",
+				owner:     "",
+				repo:      "go",
+				project:   "go",
+				path:      "src/net/url/url.go",
+				branch:    "master",
+				ref:       api.Ref{Kind: api.RefKindBranch, Name: "master"},
+				provider:  "gitiles",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://go.googlesource.com/go/+/4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a/src/net/url/url.go",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "go.googlesource.com",
+				href:      "https://go.googlesource.com/go/+/4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a/src/net/url/url.go",
+				owner:     "",
+				repo:      "go",
+				project:   "go",
+				path:      "src/net/url/url.go",
+				branch:    "",
+				ref:       api.Ref{Kind: api.RefKindCommit, Name: "4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a"},
+				provider:  "gitiles",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://go.googlesource.com/go/+/refs/tags/go1.22.0",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "go.googlesource.com",
+				href:      "https://go.googlesource.com/go/+/refs/tags/go1.22.0",
+				owner:     "",
+				repo:      "go",
+				project:   "go",
+				path:      "",
+				branch:    "",
+				ref:       api.Ref{Kind: api.RefKindTag, Name: "go1.22.0"},
+				provider:  "gitiles",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://go.googlesource.com/go/+log/refs/heads/master/src",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "go.googlesource.com",
+				href:      "https://go.googlesource.com/go/+log/refs/heads/master/src",
+				owner:     "",
+				repo:      "go",
+				project:   "go",
+				path:      "src",
+				branch:    "master",
+				ref:       api.Ref{Kind: api.RefKindBranch, Name: "master"},
+				provider:  "gitiles",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://android.googlesource.com/platform/frameworks/base",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "android.googlesource.com",
+				href:      "https://android.googlesource.com/platform/frameworks/base",
+				owner:     "platform/frameworks",
+				repo:      "base",
+				project:   "platform/frameworks/base",
+				path:      "",
+				branch:    "",
+				ref:       api.Ref{},
+				provider:  "gitiles",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://android.googlesource.com/a/platform/frameworks/base/+/refs/heads/main/core/java/Foo.java",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "android.googlesource.com",
+				href:      "https://android.googlesource.com/a/platform/frameworks/base/+/refs/heads/main/core/java/Foo.java",
+				owner:     "platform/frameworks",
+				repo:      "base",
+				project:   "platform/frameworks/base",
+				path:      "core/java/Foo.java",
+				branch:    "main",
+				ref:       api.Ref{Kind: api.RefKindBranch, Name: "main"},
+				provider:  "gitiles",
+			},
+			wantErr: false,
+		},
+		// failure cases
+		{
+			input:   "https://go.googlesource.com/",
+			want:    &repository{},
+			wantErr: true,
+		},
+		{
+			input:   "git@go.googlesource.com:go.git",
+			want:    &repository{},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		var got pkg.RepositoryManager
+		got, err := suite.rm.Parse(tc.input)
+
+		if tc.wantErr {
+			assert.Error(suite.T(), err)
+		} else {
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tc.want.protocol, got.GetProtocol())
+			assert.Equal(suite.T(), tc.want.protocols, got.GetProtocols())
+			assert.Equal(suite.T(), tc.want.resource, got.GetResourceName())
+			assert.Equal(suite.T(), tc.want.href, got.GetHREF())
+			assert.Equal(suite.T(), tc.want.owner, got.GetOwnerName())
+			assert.Equal(suite.T(), tc.want.repo, got.GetRepoName())
+			assert.Equal(suite.T(), tc.want.project, got.GetProjectName())
+			assert.Equal(suite.T(), tc.want.path, got.GetPath())
+			assert.Equal(suite.T(), tc.want.branch, got.GetBranchName())
+			assert.Equal(suite.T(), tc.want.ref, got.GetRef())
+			assert.Equal(suite.T(), tc.want.provider, got.GetProviderName())
+		}
+	}
+}
+
+func (suite *ParserPublicTestSuite) TestParseRefAmbiguous() {
+	type test struct {
+		input string
+		want  bool
+	}
+
+	tests := []test{
+		{
+			input: "https://go.googlesource.com/go/+/refs/heads/feature/login/src",
+			want:  true,
+		},
+		{
+			input: "https://go.googlesource.com/go/+/refs/tags/release/v1.0/x.go",
+			want:  true,
+		},
+		// failure cases
+		{
+			input: "https://go.googlesource.com/go/+/4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a/src",
+			want:  false,
+		},
+		{
+			input: "https://go.googlesource.com/go/+/refs/heads/main",
+			want:  false,
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := suite.rm.Parse(tc.input)
+		require.NoError(suite.T(), err, tc.input)
+
+		assert.Equal(suite.T(), tc.want, got.GetRefAmbiguous(), tc.input)
+	}
+}
+
+// In order for `go test` to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run.
+func TestParserPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(ParserPublicTestSuite))
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package gitiles
+
+import (
+	"fmt"
+
+	"github.com/retr0h/git-url-parse/internal/repositories"
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+// CloneRemote return the parts of the Gitiles repo's clone URL.  Only HTTPS
+// is served, as in `https://go.googlesource.com/go`.
+func (g *Gitiles) CloneRemote(
+	repo *api.Repository,
+	format api.CloneFormat,
+) (api.Remote, error) {
+	if format != api.CloneFormatHTTPS {
+		return api.Remote{}, fmt.Errorf("clone format: %s not supported by provider: %s", format, providerName)
+	}
+
+	if repo.Resource == "" || repo.Project == "" {
+		return api.Remote{}, fmt.Errorf("repo: %s has no host or name to format", repo.HREF)
+	}
+
+	return api.Remote{
+		Host: repo.Resource,
+		Path: repo.Project,
+	}, nil
+}
+
+// WebURL return the URL of the Gitiles repo's web page, as in
+// `https://go.googlesource.com/go/+log/master/README.md`.
+func (g *Gitiles) WebURL(
+	repo *api.Repository,
+	view api.View,
+	location api.Location,
+) (string, error) {
+	return fmt.Sprintf("%s/%s/%s",
+		repositories.WebBase(repo, repo.Resource),
+		repo.Project,
+		repositories.GitilesWebPath(view, location),
+	), nil
+}
@@ -26,7 +26,9 @@ import (
 )
 
 const (
-	defaultHost string = "gitlab"
+	defaultHost    string = "gitlab.com"
+	wwwHost        string = "www.gitlab.com"
+	heuristicLabel string = "gitlab."
 )
 
 // New factory to create a new GitLab instance.
@@ -38,7 +40,15 @@ func New(
 	}
 }
 
-// ShouldParse determine if the provided URL belongs to GitLab.
+// ShouldParse determine if the provided URL belongs to GitLab.  Self-managed
+// GitLab instances must be declared with `repository.Registry.RegisterHost`,
+// or matched with MatchHeuristic.
 func (gl *GitLab) ShouldParse(host string) bool {
-	return strings.Contains(host, defaultHost)
+	return host == defaultHost || host == wwwHost
+}
+
+// MatchHeuristic determine if the provided host looks like a self-managed
+// GitLab instance, as in `gitlab.example.com`.
+func (gl *GitLab) MatchHeuristic(host string) bool {
+	return strings.HasPrefix(host, heuristicLabel)
 }
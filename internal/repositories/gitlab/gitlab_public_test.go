@@ -58,10 +58,22 @@ func (suite *GitLabPublicTestSuite) TestShouldParse() {
 			want:  true,
 		},
 		{
-			input: "gitlab.example.com",
+			input: "www.gitlab.com",
 			want:  true,
 		},
 		// failure cases
+		{
+			input: "gitlab.example.com",
+			want:  false,
+		},
+		{
+			input: "notgitlab.example.com",
+			want:  false,
+		},
+		{
+			input: "gitlab-mirror.github.io",
+			want:  false,
+		},
 		{
 			input: "example.com",
 			want:  false,
@@ -83,6 +95,39 @@ func (suite *GitLabPublicTestSuite) TestShouldParse() {
 	}
 }
 
+func (suite *GitLabPublicTestSuite) TestMatchHeuristic() {
+	type test struct {
+		input string
+		want  bool
+	}
+
+	tests := []test{
+		{
+			input: "gitlab.example.com",
+			want:  true,
+		},
+		// failure cases
+		{
+			input: "notgitlab.example.com",
+			want:  false,
+		},
+		{
+			input: "gitlab-mirror.github.io",
+			want:  false,
+		},
+		{
+			input: "code.example.com",
+			want:  false,
+		},
+	}
+
+	for _, tc := range tests {
+		got := gitlab.New(suite.logger).MatchHeuristic(tc.input)
+
+		assert.Equal(suite.T(), tc.want, got)
+	}
+}
+
 // In order for `go test` to run this suite, we need to create
 // a normal test function and pass our suite to suite.Run.
 func TestGitLabPublicTestSuite(t *testing.T) {
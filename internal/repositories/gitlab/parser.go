@@ -21,58 +21,138 @@
 package gitlab
 
 import (
-	"fmt"
+	"context"
 	"log/slog"
-	"regexp"
+	"strings"
 
 	"github.com/retr0h/git-url-parse/internal/repositories"
 	"github.com/retr0h/git-url-parse/pkg/api"
+	"github.com/retr0h/git-url-parse/pkg/giturl"
 )
 
 const (
 	providerName string = "gitlab"
+	// segment a namespace or project path component, which GitLab forbids
+	// from starting with `-`, so the `/-/` separator is never mistaken for
+	// a subgroup.
+	segment string = `[^/-][^/]*`
+	// project matches a web URL's path up to the project, whose pages follow
+	// `/-/`.
+	project string = `^/(?P<owner>` + segment + `)(?P<subgroups>(?:/` + segment + `)*)/(?P<repo>` + segment + `)`
+)
+
+var (
+	webSchemes = []string{"http", "https"}
+	sshSchemes = []string{"ssh", "git+ssh", "git"}
+	scpSchemes = []string{repositories.SchemeSCPLike}
 )
 
 // ChatGPT-4 generated regexp
-var patterns = []string{
+var patterns = repositories.MustCompile([]repositories.Pattern{
 	// TODO(retr0h): improve list of regexp
-	`^(?P<scheme>https?)://(?P<resource>[^/]+)/(?P<owner>[^/]+)/(?P<repo>[^/]+)(/(?:tree|blob)/(?P<branch>[^/]+)(?P<path>/.*)?)?$`,
-	`^(?P<scheme>https)://(?P<resource>gitlab\.com)/(?P<owner>[^/]+)/(?P<repo>[^/]+)/-/blob/(?P<branch>[^/]+)/(?P<path>.+)$`,
-	`^(?P<scheme>https)://(?P<resource>gitlab\.com)/(?P<owner>[^/]+)/(?P<repo>[^/]+)/-/tree/(?P<branch>[^/]+)$`,
-	`^(?P<scheme>https)://(?P<resource>gitlab\.com)/(?P<owner>[^/]+)/(?P<repo>[^/]+)/-/raw/(?P<branch>[^/]+)/(?P<path>.*)$`,
-	`^(?P<scheme>https)://(?P<resource>gitlab\.com)/(?P<owner>[^/]+)/((?P<subgroup>[^/]+)/)?(?P<repo>[^/]+)\.git$`,
-	`^(?P<scheme>https)://(?P<resource>gitlab\.com)/(?P<owner>[^/]+)(?P<subgroups>(?:/[^/]+)*)/(?P<repo>[^/]+)\.git$`,
-	`^(?P<scheme>https)://(?P<resource>gitlab\.[^/]+)/(?P<owner>[^/]+)/(?P<repo>[^/]+)$`,
-	`^(?P<scheme>git)@(?P<resource>gitlab\.com):(?P<owner>[^/]+)/(?P<repo>[^/]+)\.git$`,
+	{Schemes: webSchemes, Path: `^/(?P<owner>[^/]+)/(?P<repo>[^/]+?)(?:\.git)?(/(?P<kind>tree|blob)/(?P<branch>[^/]+)(?:/(?P<path>.*))?)?$`},
+	{Schemes: webSchemes, Path: project + `/-/(?P<kind>blob|blame)/(?P<branch>[^/]+)/(?P<path>.+)$`},
+	{Schemes: webSchemes, Path: project + `/-/(?P<kind>tree|commits)/(?P<branch>[^/]+)(?:/(?P<path>.+))?$`},
+	{Schemes: webSchemes, Path: project + `/-/(?P<kind>raw)/(?P<branch>[^/]+)/(?P<path>.*)$`},
+	{Schemes: webSchemes, Path: project + `/-/(?P<kind>merge_requests|issues)(?:/(?P<number>\d+)(?:/.*)?)?$`},
+	{Schemes: webSchemes, Path: project + `/-/(?P<kind>commit)/(?P<commit>[0-9a-fA-F]+)(?:/.*)?$`},
+	{Schemes: webSchemes, Path: project + `/-/(?P<kind>compare)/(?P<compare>.+)$`},
+	{Schemes: webSchemes, Path: project + `/-/(?P<kind>releases|tags)(?:/(?P<tag>[^/]+))?$`},
+	{Schemes: webSchemes, Path: project + `/-/(?P<kind>pipelines)/(?P<number>\d+)(?:/.*)?$`},
+	{Schemes: webSchemes, Path: project + `/-/(?P<kind>wikis)(?:/(?P<path>.+))?$`},
+	{Schemes: webSchemes, Path: `^/(?P<owner>` + segment + `)(?P<subgroups>(?:/` + segment + `)*)/(?P<repo>` + segment + `?)(?:\.git)?$`},
+	{Schemes: sshSchemes, Path: `^/(?P<owner>` + segment + `)(?P<subgroups>(?:/` + segment + `)*)/(?P<repo>` + segment + `?)(?:\.git)?$`},
+	{Schemes: scpSchemes, Path: `^(?P<owner>` + segment + `)(?P<subgroups>(?:/` + segment + `)*)/(?P<repo>` + segment + `?)\.git$`},
+})
+
+// kinds the kind of page named by each pattern's `kind`, clone URLs name
+// none.
+var kinds = map[string]api.Kind{
+	"":               api.KindRepo,
+	"tree":           api.KindTree,
+	"blob":           api.KindBlob,
+	"raw":            api.KindRaw,
+	"blame":          api.KindBlame,
+	"commits":        api.KindHistory,
+	"commit":         api.KindCommit,
+	"merge_requests": api.KindPullRequest,
+	"issues":         api.KindIssue,
+	"compare":        api.KindCompare,
+	"releases":       api.KindRelease,
+	"tags":           api.KindTag,
+	"pipelines":      api.KindRun,
+	"wikis":          api.KindWiki,
 }
 
 // Parse the provided GitLab URL.
 func (gh *GitLab) Parse(url string) (*api.Repository, error) {
+	return repositories.Parse(gh, url)
+}
+
+// ParseURL parse the tokens of the provided, redacted, GitLab URL.
+func (gh *GitLab) ParseURL(url string, u *giturl.URL) (*api.Repository, error) {
 	for _, pattern := range patterns {
-		re := regexp.MustCompile(pattern)
-		matches := re.FindStringSubmatch(url)
-		mm := repositories.MakeMatchMap(re, matches)
+		mm := pattern.Match(u)
 
-		gh.logger.Debug(
+		gh.logger.LogAttrs(
+			context.Background(),
+			slog.LevelDebug,
 			"matching url",
 			slog.String("url", url),
-			slog.String("regexp", pattern),
+			slog.String("regexp", pattern.Path),
 		)
 
-		if matches != nil {
+		if mm != nil {
+			branch, ref, path := repositories.MatchRef(mm)
+			namespace, subgroups := splitNamespace(mm["owner"], mm["subgroups"])
+			base, head := repositories.SplitCompare(mm["compare"])
+
+			kind := kinds[mm["kind"]]
+			lineStart, lineEnd := repositories.FileLines(
+				repositories.IsFileKind(kind),
+				repositories.LinesGitLab,
+				u.Fragment,
+			)
+
 			return &api.Repository{
-				Protocol: mm["scheme"],
-				Host:     mm["resource"],
-				Provider: providerName,
-				Resource: mm["resource"],
-				Owner:    mm["owner"],
-				Repo:     mm["repo"],
-				Path:     mm["path"],
-				Branch:   mm["branch"],
-				HREF:     url,
+				Protocol:  repositories.Protocol(u),
+				Host:      u.Host,
+				Provider:  providerName,
+				Resource:  u.Host,
+				Owner:     mm["owner"],
+				Namespace: namespace,
+				Subgroups: subgroups,
+				Repo:      mm["repo"],
+				Path:      path,
+				LineStart: lineStart,
+				LineEnd:   lineEnd,
+				Branch:    branch,
+				Kind:      kind,
+				Number:    mm["number"],
+				Commit:    mm["commit"],
+				Base:      base,
+				Head:      head,
+				Ref:       ref,
+				User:      u.User,
+				Port:      u.Port,
+				Pathname:  u.Path,
+				HREF:      url,
+
+				// branches may contain `/`, and run into the path
+				RefAmbiguous: repositories.IsRefAmbiguous(ref, path),
 			}, nil
 		}
 	}
 
-	return nil, fmt.Errorf("could match url: %s to any pattern", url)
+	return nil, repositories.NoMatch(url, u, providerName, patterns)
+}
+
+// splitNamespace join the top-level group and the captured `/`-prefixed
+// subgroups into the full namespace path, and the list of subgroups.
+func splitNamespace(owner string, subgroups string) (string, []string) {
+	if subgroups == "" {
+		return owner, nil
+	}
+
+	return owner + subgroups, strings.Split(strings.TrimPrefix(subgroups, "/"), "/")
 }
@@ -32,6 +32,7 @@ import (
 	"github.com/retr0h/git-url-parse/internal"
 	"github.com/retr0h/git-url-parse/internal/repositories/gitlab"
 	"github.com/retr0h/git-url-parse/pkg"
+	"github.com/retr0h/git-url-parse/pkg/api"
 )
 
 type ParserPublicTestSuite struct {
@@ -52,6 +53,7 @@ func (suite *ParserPublicTestSuite) TestParse() {
 	type repository struct {
 		branch    string
 		href      string
+		namespace string
 		owner     string
 		path      string
 		protocol  string
@@ -59,6 +61,7 @@ func (suite *ParserPublicTestSuite) TestParse() {
 		provider  string
 		repo      string
 		resource  string
+		subgroups []string
 	}
 
 	type test struct {
@@ -75,6 +78,7 @@ func (suite *ParserPublicTestSuite) TestParse() {
 				protocols: []string{"https"},
 				resource:  "gitlab.com",
 				owner:     "owner",
+				namespace: "owner",
 				repo:      "repository",
 				path:      "",
 				branch:    "",
@@ -90,6 +94,7 @@ func (suite *ParserPublicTestSuite) TestParse() {
 				protocols: []string{"https"},
 				resource:  "gitlab.com",
 				owner:     "owner",
+				namespace: "owner",
 				repo:      "repository",
 				path:      "stable/acs-engine-autoscaler/Chart.yaml",
 				branch:    "main",
@@ -105,6 +110,7 @@ func (suite *ParserPublicTestSuite) TestParse() {
 				protocols: []string{"https"},
 				resource:  "gitlab.com",
 				owner:     "owner",
+				namespace: "owner",
 				repo:      "repository",
 				path:      "README.md",
 				branch:    "dev",
@@ -113,6 +119,54 @@ func (suite *ParserPublicTestSuite) TestParse() {
 			},
 			wantErr: false,
 		},
+		{
+			input: "https://gitlab.com/owner/repository/tree/main/docs/x",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "gitlab.com",
+				owner:     "owner",
+				namespace: "owner",
+				repo:      "repository",
+				path:      "docs/x",
+				branch:    "main",
+				provider:  "gitlab",
+				href:      "https://gitlab.com/owner/repository/tree/main/docs/x",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://gitlab.com/owner/repository/-/tree/main/docs/x",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "gitlab.com",
+				owner:     "owner",
+				namespace: "owner",
+				repo:      "repository",
+				path:      "docs/x",
+				branch:    "main",
+				provider:  "gitlab",
+				href:      "https://gitlab.com/owner/repository/-/tree/main/docs/x",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://gitlab.com/owner/repository/blob/main/README.md",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "gitlab.com",
+				owner:     "owner",
+				namespace: "owner",
+				repo:      "repository",
+				path:      "README.md",
+				branch:    "main",
+				provider:  "gitlab",
+				href:      "https://gitlab.com/owner/repository/blob/main/README.md",
+			},
+			wantErr: false,
+		},
 		{
 			input: "https://gitlab.com/owner/repository/-/tree/dev",
 			want: &repository{
@@ -120,6 +174,7 @@ func (suite *ParserPublicTestSuite) TestParse() {
 				protocols: []string{"https"},
 				resource:  "gitlab.com",
 				owner:     "owner",
+				namespace: "owner",
 				repo:      "repository",
 				path:      "",
 				branch:    "dev",
@@ -135,6 +190,7 @@ func (suite *ParserPublicTestSuite) TestParse() {
 				protocols: []string{"https"},
 				resource:  "gitlab.com",
 				owner:     "owner",
+				namespace: "owner",
 				repo:      "repository",
 				path:      "README.md",
 				branch:    "v0.0.0",
@@ -150,6 +206,7 @@ func (suite *ParserPublicTestSuite) TestParse() {
 				protocols: []string{"https"},
 				resource:  "gitlab.com",
 				owner:     "owner",
+				namespace: "owner",
 				repo:      "repository",
 				path:      "stable/acs-engine-autoscaler/Chart.yaml",
 				branch:    "main",
@@ -165,6 +222,8 @@ func (suite *ParserPublicTestSuite) TestParse() {
 				protocols: []string{"https"},
 				resource:  "gitlab.com",
 				owner:     "owner",
+				namespace: "owner/subgroup",
+				subgroups: []string{"subgroup"},
 				repo:      "repository",
 				path:      "",
 				branch:    "",
@@ -180,6 +239,8 @@ func (suite *ParserPublicTestSuite) TestParse() {
 				protocols: []string{"https"},
 				resource:  "gitlab.com",
 				owner:     "owner",
+				namespace: "owner/subgroup/subsubgroup",
+				subgroups: []string{"subgroup", "subsubgroup"},
 				repo:      "repository",
 				path:      "",
 				branch:    "",
@@ -195,6 +256,7 @@ func (suite *ParserPublicTestSuite) TestParse() {
 				protocols: []string{"https"},
 				resource:  "gitlab.example.com",
 				owner:     "owner",
+				namespace: "owner",
 				repo:      "repository",
 				path:      "",
 				branch:    "",
@@ -203,7 +265,6 @@ func (suite *ParserPublicTestSuite) TestParse() {
 			},
 			wantErr: false,
 		},
-
 		{
 			input: "git@gitlab.com:owner/repository.git",
 			want: &repository{
@@ -211,6 +272,7 @@ func (suite *ParserPublicTestSuite) TestParse() {
 				protocols: []string{"git"},
 				resource:  "gitlab.com",
 				owner:     "owner",
+				namespace: "owner",
 				repo:      "repository",
 				path:      "",
 				branch:    "",
@@ -219,6 +281,141 @@ func (suite *ParserPublicTestSuite) TestParse() {
 			},
 			wantErr: false,
 		},
+		{
+			input: "https://gitlab.com/group/sub/subsub/repository",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "gitlab.com",
+				owner:     "group",
+				namespace: "group/sub/subsub",
+				subgroups: []string{"sub", "subsub"},
+				repo:      "repository",
+				path:      "",
+				branch:    "",
+				provider:  "gitlab",
+				href:      "https://gitlab.com/group/sub/subsub/repository",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://gitlab.com/group/sub/subsub/repository/-/blob/main/docs/README.md",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "gitlab.com",
+				owner:     "group",
+				namespace: "group/sub/subsub",
+				subgroups: []string{"sub", "subsub"},
+				repo:      "repository",
+				path:      "docs/README.md",
+				branch:    "main",
+				provider:  "gitlab",
+				href:      "https://gitlab.com/group/sub/subsub/repository/-/blob/main/docs/README.md",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://gitlab.com/group/sub/subsub/repository/-/tree/main",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "gitlab.com",
+				owner:     "group",
+				namespace: "group/sub/subsub",
+				subgroups: []string{"sub", "subsub"},
+				repo:      "repository",
+				path:      "",
+				branch:    "main",
+				provider:  "gitlab",
+				href:      "https://gitlab.com/group/sub/subsub/repository/-/tree/main",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://gitlab.com/group/sub/repository/-/tree/main/docs",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "gitlab.com",
+				owner:     "group",
+				namespace: "group/sub",
+				subgroups: []string{"sub"},
+				repo:      "repository",
+				path:      "docs",
+				branch:    "main",
+				provider:  "gitlab",
+				href:      "https://gitlab.com/group/sub/repository/-/tree/main/docs",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://gitlab.com/group/sub/repository/-/raw/main/README.md",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "gitlab.com",
+				owner:     "group",
+				namespace: "group/sub",
+				subgroups: []string{"sub"},
+				repo:      "repository",
+				path:      "README.md",
+				branch:    "main",
+				provider:  "gitlab",
+				href:      "https://gitlab.com/group/sub/repository/-/raw/main/README.md",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://code.example.com/owner/repository/-/blob/main/README.md",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "code.example.com",
+				owner:     "owner",
+				namespace: "owner",
+				repo:      "repository",
+				path:      "README.md",
+				branch:    "main",
+				provider:  "gitlab",
+				href:      "https://code.example.com/owner/repository/-/blob/main/README.md",
+			},
+			wantErr: false,
+		},
+		{
+			input: "git@gitlab.com:group/sub/subsub/repository.git",
+			want: &repository{
+				protocol:  "git",
+				protocols: []string{"git"},
+				resource:  "gitlab.com",
+				owner:     "group",
+				namespace: "group/sub/subsub",
+				subgroups: []string{"sub", "subsub"},
+				repo:      "repository",
+				path:      "",
+				branch:    "",
+				provider:  "gitlab",
+				href:      "git@gitlab.com:group/sub/subsub/repository.git",
+			},
+			wantErr: false,
+		},
+		{
+			input: "ssh://git@gitlab.com/group/sub/subsub/repository.git",
+			want: &repository{
+				protocol:  "ssh",
+				protocols: []string{"ssh"},
+				resource:  "gitlab.com",
+				owner:     "group",
+				namespace: "group/sub/subsub",
+				subgroups: []string{"sub", "subsub"},
+				repo:      "repository",
+				path:      "",
+				branch:    "",
+				provider:  "gitlab",
+				href:      "ssh://git@gitlab.com/group/sub/subsub/repository.git",
+			},
+			wantErr: false,
+		},
 		// failure cases
 		{
 			input:   "https://gitlab.com/",
@@ -231,7 +428,7 @@ func (suite *ParserPublicTestSuite) TestParse() {
 			wantErr: true,
 		},
 		{
-			input:   "git@gitlab.com:foobar/owner/repository.git",
+			input:   "git@gitlab.com:owner/repository",
 			want:    &repository{},
 			wantErr: true,
 		},
@@ -250,6 +447,8 @@ func (suite *ParserPublicTestSuite) TestParse() {
 			assert.Equal(suite.T(), tc.want.resource, got.GetResourceName())
 			assert.Equal(suite.T(), tc.want.href, got.GetHREF())
 			assert.Equal(suite.T(), tc.want.owner, got.GetOwnerName())
+			assert.Equal(suite.T(), tc.want.namespace, got.GetNamespace())
+			assert.Equal(suite.T(), tc.want.subgroups, got.GetSubgroups())
 			assert.Equal(suite.T(), tc.want.repo, got.GetRepoName())
 			assert.Equal(suite.T(), tc.want.path, got.GetPath())
 			assert.Equal(suite.T(), tc.want.branch, got.GetBranchName())
@@ -258,6 +457,112 @@ func (suite *ParserPublicTestSuite) TestParse() {
 	}
 }
 
+func (suite *ParserPublicTestSuite) TestParseKind() {
+	type test struct {
+		input  string
+		kind   api.Kind
+		number string
+		commit string
+		base   string
+		head   string
+		tag    string
+		branch string
+		path   string
+	}
+
+	tests := []test{
+		{
+			input: "git@gitlab.com:group/sub/project.git",
+			kind:  api.KindRepo,
+		},
+		{
+			input:  "https://gitlab.com/group/sub/project/-/tree/main",
+			kind:   api.KindTree,
+			branch: "main",
+		},
+		{
+			input:  "https://gitlab.com/group/sub/project/-/blob/main/x.go",
+			kind:   api.KindBlob,
+			branch: "main",
+			path:   "x.go",
+		},
+		{
+			input:  "https://gitlab.com/group/sub/project/-/raw/main/x.go",
+			kind:   api.KindRaw,
+			branch: "main",
+			path:   "x.go",
+		},
+		{
+			input:  "https://gitlab.com/group/sub/project/-/blame/main/x.go",
+			kind:   api.KindBlame,
+			branch: "main",
+			path:   "x.go",
+		},
+		{
+			input:  "https://gitlab.com/group/sub/project/-/commits/main",
+			kind:   api.KindHistory,
+			branch: "main",
+		},
+		{
+			input:  "https://gitlab.com/group/sub/project/-/merge_requests/12/diffs",
+			kind:   api.KindPullRequest,
+			number: "12",
+		},
+		{
+			input:  "https://gitlab.com/group/sub/project/-/issues/34",
+			kind:   api.KindIssue,
+			number: "34",
+		},
+		{
+			input:  "https://gitlab.com/group/sub/project/-/commit/4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a",
+			kind:   api.KindCommit,
+			commit: "4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a",
+		},
+		{
+			input: "https://gitlab.com/group/sub/project/-/compare/main...feature?from_project_id=1",
+			kind:  api.KindCompare,
+			base:  "main",
+			head:  "feature",
+		},
+		{
+			input: "https://gitlab.com/group/sub/project/-/releases/v1.0.0",
+			kind:  api.KindRelease,
+			tag:   "v1.0.0",
+		},
+		{
+			input: "https://gitlab.com/group/sub/project/-/tags/v1.0.0",
+			kind:  api.KindTag,
+			tag:   "v1.0.0",
+		},
+		{
+			input:  "https://gitlab.com/group/sub/project/-/pipelines/56",
+			kind:   api.KindRun,
+			number: "56",
+		},
+		{
+			input: "https://gitlab.com/group/sub/project/-/wikis/home",
+			kind:  api.KindWiki,
+			path:  "home",
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := suite.rm.Parse(tc.input)
+		require.NoError(suite.T(), err, tc.input)
+
+		assert.Equal(suite.T(), tc.kind, got.GetKind(), tc.input)
+		assert.Equal(suite.T(), tc.number, got.GetNumber(), tc.input)
+		assert.Equal(suite.T(), tc.commit, got.GetCommit(), tc.input)
+		assert.Equal(suite.T(), tc.base, got.GetBase(), tc.input)
+		assert.Equal(suite.T(), tc.head, got.GetHead(), tc.input)
+		if tc.tag != "" {
+			assert.Equal(suite.T(), api.Ref{Kind: api.RefKindTag, Name: tc.tag}, got.GetRef(), tc.input)
+		}
+		assert.Equal(suite.T(), tc.branch, got.GetBranchName(), tc.input)
+		assert.Equal(suite.T(), tc.path, got.GetPath(), tc.input)
+	}
+}
+
 // In order for `go test` to run this suite, we need to create
 // a normal test function and pass our suite to suite.Run.
 func TestParserPublicTestSuite(t *testing.T) {
@@ -0,0 +1,121 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package sourcehut
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/retr0h/git-url-parse/internal/repositories"
+	"github.com/retr0h/git-url-parse/pkg/api"
+	"github.com/retr0h/git-url-parse/pkg/giturl"
+)
+
+const (
+	providerName string = "sourcehut"
+)
+
+var patterns = repositories.MustCompile([]repositories.Pattern{
+	{
+		Schemes: []string{"http", "https"},
+		Path:    `^/~(?P<owner>[^/]+)/(?P<repo>[^/]+)/tree/(?P<branch>.+?)(?:/item/(?P<path>.*))?$`,
+	},
+	{
+		Schemes: []string{"http", "https"},
+		Path:    `^/~(?P<owner>[^/]+)/(?P<repo>[^/]+)/(?P<view>blob)/(?P<branch>[^/]+)/(?P<path>.*)$`,
+	},
+	{
+		Schemes: []string{"http", "https"},
+		Path:    `^/~(?P<owner>[^/]+)/(?P<repo>[^/]+?)(?:\.git)?/?$`,
+	},
+	{
+		Schemes: []string{"ssh", "git+ssh"},
+		Path:    `^/~(?P<owner>[^/]+)/(?P<repo>[^/]+?)(?:\.git)?$`,
+	},
+	{
+		Schemes: []string{repositories.SchemeSCPLike},
+		Path:    `^~(?P<owner>[^/]+)/(?P<repo>[^/]+?)(?:\.git)?$`,
+	},
+})
+
+// Parse the provided SourceHut URL.  The `~` is stripped from the owner.
+func (s *SourceHut) Parse(url string) (*api.Repository, error) {
+	return repositories.Parse(s, url)
+}
+
+// ParseURL parse the tokens of the provided, redacted, SourceHut URL.
+func (s *SourceHut) ParseURL(url string, u *giturl.URL) (*api.Repository, error) {
+	if u.Host == mercurialHost {
+		return nil, &api.NotGitError{
+			URL:      url,
+			Host:     u.Host,
+			Provider: providerName,
+			VCS:      "mercurial",
+		}
+	}
+
+	for _, pattern := range patterns {
+		mm := pattern.Match(u)
+
+		s.logger.LogAttrs(
+			context.Background(),
+			slog.LevelDebug,
+			"matching url",
+			slog.String("url", url),
+			slog.String("regexp", pattern.Path),
+		)
+
+		if mm != nil {
+			branch, ref, path := repositories.MatchRef(mm)
+
+			lineStart, lineEnd := repositories.FileLines(
+				path != "",
+				repositories.LinesSourceHut,
+				u.Fragment,
+			)
+
+			return &api.Repository{
+				Protocol:  repositories.Protocol(u),
+				Host:      u.Host,
+				Provider:  providerName,
+				Resource:  u.Host,
+				Owner:     mm["owner"],
+				Repo:      mm["repo"],
+				Path:      strings.TrimSuffix(path, "/"),
+				LineStart: lineStart,
+				LineEnd:   lineEnd,
+				Branch:    branch,
+				Ref:       ref,
+				User:      u.User,
+				Port:      u.Port,
+				Pathname:  u.Path,
+				HREF:      url,
+
+				// `/item/` ends the ref of tree URLs, blob URLs mark no end
+				RefAmbiguous: mm["view"] == "blob" &&
+					repositories.IsRefAmbiguous(ref, path),
+			}, nil
+		}
+	}
+
+	return nil, repositories.NoMatch(url, u, providerName, patterns)
+}
@@ -0,0 +1,301 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package sourcehut_test
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/retr0h/git-url-parse/internal"
+	"github.com/retr0h/git-url-parse/internal/repositories/sourcehut"
+	"github.com/retr0h/git-url-parse/pkg"
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+type ParserPublicTestSuite struct {
+	suite.Suite
+
+	rm internal.ParserManager
+
+	logger *slog.Logger
+}
+
+func (suite *ParserPublicTestSuite) SetupTest() {
+	suite.logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	suite.rm = sourcehut.New(suite.logger)
+}
+
+func (suite *ParserPublicTestSuite) TestParse() {
+	type repository struct {
+		branch    string
+		href      string
+		owner     string
+		path      string
+		protocol  string
+		protocols []string
+		provider  string
+		repo      string
+		resource  string
+	}
+
+	type test struct {
+		input   string
+		want    *repository
+		wantErr bool
+	}
+
+	tests := []test{
+		{
+			input: "https://git.sr.ht/~user/repository",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "git.sr.ht",
+				href:      "https://git.sr.ht/~user/repository",
+				owner:     "user",
+				repo:      "repository",
+				path:      "",
+				branch:    "",
+				provider:  "sourcehut",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://git.sr.ht/~user/repository/",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "git.sr.ht",
+				href:      "https://git.sr.ht/~user/repository/",
+				owner:     "user",
+				repo:      "repository",
+				path:      "",
+				branch:    "",
+				provider:  "sourcehut",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://git.sr.ht/~user/repository/tree/main/item/docs/README.md",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "git.sr.ht",
+				href:      "https://git.sr.ht/~user/repository/tree/main/item/docs/README.md",
+				owner:     "user",
+				repo:      "repository",
+				path:      "docs/README.md",
+				branch:    "main",
+				provider:  "sourcehut",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://git.sr.ht/~user/repository/tree/main",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "git.sr.ht",
+				href:      "https://git.sr.ht/~user/repository/tree/main",
+				owner:     "user",
+				repo:      "repository",
+				path:      "",
+				branch:    "main",
+				provider:  "sourcehut",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://git.sr.ht/~user/repository/tree/feature/login/item/docs/README.md",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "git.sr.ht",
+				href:      "https://git.sr.ht/~user/repository/tree/feature/login/item/docs/README.md",
+				owner:     "user",
+				repo:      "repository",
+				path:      "docs/README.md",
+				branch:    "feature/login",
+				provider:  "sourcehut",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://git.sr.ht/~user/repository/tree/feature/login",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "git.sr.ht",
+				href:      "https://git.sr.ht/~user/repository/tree/feature/login",
+				owner:     "user",
+				repo:      "repository",
+				path:      "",
+				branch:    "feature/login",
+				provider:  "sourcehut",
+			},
+			wantErr: false,
+		},
+		{
+			input: "https://git.sr.ht/~user/repository/blob/v1.0.0/go.mod",
+			want: &repository{
+				protocol:  "https",
+				protocols: []string{"https"},
+				resource:  "git.sr.ht",
+				href:      "https://git.sr.ht/~user/repository/blob/v1.0.0/go.mod",
+				owner:     "user",
+				repo:      "repository",
+				path:      "go.mod",
+				branch:    "v1.0.0",
+				provider:  "sourcehut",
+			},
+			wantErr: false,
+		},
+		{
+			input: "git@git.sr.ht:~user/repository",
+			want: &repository{
+				protocol:  "git",
+				protocols: []string{"git"},
+				resource:  "git.sr.ht",
+				href:      "git@git.sr.ht:~user/repository",
+				owner:     "user",
+				repo:      "repository",
+				path:      "",
+				branch:    "",
+				provider:  "sourcehut",
+			},
+			wantErr: false,
+		},
+		{
+			input: "ssh://git@git.sr.ht/~user/repository",
+			want: &repository{
+				protocol:  "ssh",
+				protocols: []string{"ssh"},
+				resource:  "git.sr.ht",
+				href:      "ssh://git@git.sr.ht/~user/repository",
+				owner:     "user",
+				repo:      "repository",
+				path:      "",
+				branch:    "",
+				provider:  "sourcehut",
+			},
+			wantErr: false,
+		},
+		// failure cases
+		{
+			input:   "https://git.sr.ht/",
+			want:    &repository{},
+			wantErr: true,
+		},
+		{
+			input:   "https://git.sr.ht/user/repository",
+			want:    &repository{},
+			wantErr: true,
+		},
+		{
+			input:   "https://hg.sr.ht/~user/repository",
+			want:    &repository{},
+			wantErr: true,
+		},
+		{
+			input:   "ssh://hg@hg.sr.ht/~user/repository",
+			want:    &repository{},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		var got pkg.RepositoryManager
+		got, err := suite.rm.Parse(tc.input)
+
+		if tc.wantErr {
+			assert.Error(suite.T(), err)
+		} else {
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tc.want.protocol, got.GetProtocol())
+			assert.Equal(suite.T(), tc.want.protocols, got.GetProtocols())
+			assert.Equal(suite.T(), tc.want.resource, got.GetResourceName())
+			assert.Equal(suite.T(), tc.want.href, got.GetHREF())
+			assert.Equal(suite.T(), tc.want.owner, got.GetOwnerName())
+			assert.Equal(suite.T(), tc.want.repo, got.GetRepoName())
+			assert.Equal(suite.T(), tc.want.path, got.GetPath())
+			assert.Equal(suite.T(), tc.want.branch, got.GetBranchName())
+			assert.Equal(suite.T(), tc.want.provider, got.GetProviderName())
+		}
+	}
+}
+
+func (suite *ParserPublicTestSuite) TestParseRefAmbiguous() {
+	type test struct {
+		input string
+		want  bool
+	}
+
+	tests := []test{
+		{
+			input: "https://git.sr.ht/~user/repository/blob/feature/login/x.go",
+			want:  true,
+		},
+		// failure cases
+		{
+			input: "https://git.sr.ht/~user/repository/blob/4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a/x.go",
+			want:  false,
+		},
+		{
+			input: "https://git.sr.ht/~user/repository/tree/feature/login/item/x.go",
+			want:  false,
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := suite.rm.Parse(tc.input)
+		require.NoError(suite.T(), err, tc.input)
+
+		assert.Equal(suite.T(), tc.want, got.GetRefAmbiguous(), tc.input)
+	}
+}
+
+func (suite *ParserPublicTestSuite) TestParseMercurialIsNotGit() {
+	_, err := suite.rm.Parse("https://hg.sr.ht/~user/repository")
+
+	assert.ErrorIs(suite.T(), err, api.ErrNotGit)
+	assert.ErrorContains(suite.T(), err, "not git")
+
+	var nge *api.NotGitError
+	require.True(suite.T(), errors.As(err, &nge))
+
+	assert.Equal(suite.T(), "https://hg.sr.ht/~user/repository", nge.URL)
+	assert.Equal(suite.T(), "hg.sr.ht", nge.Host)
+	assert.Equal(suite.T(), "sourcehut", nge.Provider)
+	assert.Equal(suite.T(), "mercurial", nge.VCS)
+}
+
+// In order for `go test` to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run.
+func TestParserPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(ParserPublicTestSuite))
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package sourcehut
+
+import (
+	"log/slog"
+)
+
+const (
+	defaultHost   string = "git.sr.ht"
+	mercurialHost string = "hg.sr.ht"
+)
+
+// New factory to create a new SourceHut instance.
+func New(
+	logger *slog.Logger,
+) *SourceHut {
+	return &SourceHut{
+		logger: logger,
+	}
+}
+
+// ShouldParse determine if the provided URL belongs to SourceHut.  Mercurial
+// hosts are accepted so that Parse can report them as not git.
+func (s *SourceHut) ShouldParse(host string) bool {
+	return host == defaultHost || host == mercurialHost
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package sourcehut
+
+import (
+	"fmt"
+
+	"github.com/retr0h/git-url-parse/internal/repositories"
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+// CloneRemote return the parts of the SourceHut repo's clone URL, whose
+// owner is prefixed with `~`, as in `git@git.sr.ht:~owner/repo`.  The git
+// protocol is not served.
+func (s *SourceHut) CloneRemote(
+	repo *api.Repository,
+	format api.CloneFormat,
+) (api.Remote, error) {
+	if format == api.CloneFormatGit {
+		return api.Remote{}, fmt.Errorf("clone format: %s not supported by provider: %s", format, providerName)
+	}
+
+	remote, err := repo.DefaultRemote(format)
+	if err != nil {
+		return api.Remote{}, err
+	}
+	remote.Path = fmt.Sprintf("~%s/%s", repo.Owner, repo.Repo)
+
+	return remote, nil
+}
+
+// WebURL return the URL of the SourceHut repo's web page, as in
+// `https://git.sr.ht/~owner/repo/tree/main/item/README.md`.  Raw files are
+// served under `blob/`.  Only the first line of a range is anchored.
+func (s *SourceHut) WebURL(
+	repo *api.Repository,
+	view api.View,
+	location api.Location,
+) (string, error) {
+	var page string
+	switch view {
+	case api.ViewTree, api.ViewBlob:
+		page = "tree/" + webRevision(location) + repositories.LineAnchor(view, location, "#L%d", "")
+	case api.ViewRaw:
+		page = "blob/" + repositories.EscapePath(location.Ref+"/"+location.Path)
+	case api.ViewBlame:
+		page = "blame/" + repositories.EscapePath(location.Ref+"/"+location.Path) +
+			repositories.LineAnchor(view, location, "#L%d", "")
+	case api.ViewHistory:
+		page = "log/" + webRevision(location)
+	case api.ViewCommit:
+		page = "commit/" + repositories.EscapePath(location.Ref)
+	default:
+		return "", fmt.Errorf("view: %s not supported by provider: %s", view, providerName)
+	}
+
+	return fmt.Sprintf("%s/~%s/%s/%s",
+		repositories.WebBase(repo, repo.Resource),
+		repo.Owner,
+		repo.Repo,
+		page,
+	), nil
+}
+
+// webRevision return the ref and path of a tree or log page, whose path
+// follows `item/`.
+func webRevision(location api.Location) string {
+	if location.Path == "" {
+		return repositories.EscapePath(location.Ref)
+	}
+
+	return repositories.EscapePath(location.Ref) + "/item/" + repositories.EscapePath(location.Path)
+}
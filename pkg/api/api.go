@@ -22,11 +22,32 @@ package api
 
 import "strings"
 
-// GetBranchName the repo's branch name.
+// GetBase the base ref of a compare page, as `main` in `main...feature`.
+func (r *Repository) GetBase() string {
+	return r.Base
+}
+
+// GetBranchName the repo's branch name, empty when its ref is a tag or a
+// commit.
 func (r *Repository) GetBranchName() string {
 	return r.Branch
 }
 
+// GetChangeNumber the repo's code review change number.
+func (r *Repository) GetChangeNumber() string {
+	return r.Change
+}
+
+// GetCommit the commit hash of a commit page.
+func (r *Repository) GetCommit() string {
+	return r.Commit
+}
+
+// GetHead the head ref of a compare page, as `feature` in `main...feature`.
+func (r *Repository) GetHead() string {
+	return r.Head
+}
+
 // GetHostName the repo's domain.
 func (r *Repository) GetHostName() string {
 	return r.Host
@@ -37,6 +58,42 @@ func (r *Repository) GetHREF() string {
 	return r.HREF
 }
 
+// GetKind the kind of page the URL points at.
+func (r *Repository) GetKind() Kind {
+	return r.Kind
+}
+
+// GetLineEnd the last line anchored by the URL, as in `#L10-L20`.
+func (r *Repository) GetLineEnd() int {
+	return r.LineEnd
+}
+
+// GetLineStart the first line anchored by the URL, as in `#L10-L20`.
+func (r *Repository) GetLineStart() int {
+	return r.LineStart
+}
+
+// GetMatchRule the rule which selected the repo's provider.
+func (r *Repository) GetMatchRule() MatchRule {
+	return r.MatchRule
+}
+
+// GetNamespace the repo's full namespace path, the top-level group followed by
+// any subgroups, e.g. `group/subgroup`.
+func (r *Repository) GetNamespace() string {
+	return r.Namespace
+}
+
+// GetNumber the number of a pull request, issue or CI run page.
+func (r *Repository) GetNumber() string {
+	return r.Number
+}
+
+// GetOrganizationName the repo's organization.
+func (r *Repository) GetOrganizationName() string {
+	return r.Organization
+}
+
 // GetOwnerName the repo's owner.
 func (r *Repository) GetOwnerName() string {
 	return r.Owner
@@ -47,6 +104,33 @@ func (r *Repository) GetPath() string {
 	return r.Path
 }
 
+// GetPathname the path of the repo's URL following the host and port, as in
+// `/owner/repo.git`.
+func (r *Repository) GetPathname() string {
+	return r.Pathname
+}
+
+// GetPort the repo's port, when the URL names one.
+func (r *Repository) GetPort() string {
+	return r.Port
+}
+
+// GetPrefix the relative URL root the repo's host is served under, as in
+// `/gitlab`.  Builders add it back to the URLs they generate.
+func (r *Repository) GetPrefix() string {
+	return r.Prefix
+}
+
+// GetProfileName the repo's credentials profile.
+func (r *Repository) GetProfileName() string {
+	return r.Profile
+}
+
+// GetProjectName the repo's project.
+func (r *Repository) GetProjectName() string {
+	return r.Project
+}
+
 // GetProtocol the repo's protocol.
 func (r *Repository) GetProtocol() string {
 	return r.Protocol
@@ -62,6 +146,23 @@ func (r *Repository) GetProviderName() string {
 	return r.Provider
 }
 
+// GetRef the repo's typed reference.
+func (r *Repository) GetRef() Ref {
+	return r.Ref
+}
+
+// GetRefAmbiguous determine if the URL's ref and path could be split at
+// another `/`, as in `/tree/feature/login/src`, and no ref resolver settled
+// the split.
+func (r *Repository) GetRefAmbiguous() bool {
+	return r.RefAmbiguous
+}
+
+// GetRegionName the repo's cloud region.
+func (r *Repository) GetRegionName() string {
+	return r.Region
+}
+
 // GetRepoName the repo's name.
 func (r *Repository) GetRepoName() string {
 	return r.Repo
@@ -71,3 +172,14 @@ func (r *Repository) GetRepoName() string {
 func (r *Repository) GetResourceName() string {
 	return r.Resource
 }
+
+// GetSubgroups the repo's subgroups, between the top-level group and the
+// repo.
+func (r *Repository) GetSubgroups() []string {
+	return r.Subgroups
+}
+
+// GetUserName the repo's user, when the URL names one.
+func (r *Repository) GetUserName() string {
+	return r.User
+}
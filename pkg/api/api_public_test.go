@@ -35,49 +35,136 @@ type APIPublicTestSuite struct {
 
 	rm pkg.RepositoryManager
 
-	branch    string
-	host      string
-	href      string
-	owner     string
-	path      string
-	protocol  string
-	protocols []string
-	provider  string
-	repo      string
-	resource  string
+	base         string
+	branch       string
+	change       string
+	commit       string
+	head         string
+	host         string
+	href         string
+	kind         api.Kind
+	lineEnd      int
+	lineStart    int
+	matchRule    api.MatchRule
+	namespace    string
+	number       string
+	org          string
+	owner        string
+	path         string
+	pathname     string
+	port         string
+	prefix       string
+	profile      string
+	project      string
+	protocol     string
+	protocols    []string
+	provider     string
+	ref          api.Ref
+	refAmbiguous bool
+	region       string
+	repo         string
+	resource     string
+	subgroups    []string
+	user         string
 }
 
 func (suite *APIPublicTestSuite) SetupTest() {
+	suite.base = "base"
 	suite.branch = "branch"
+	suite.change = "change"
+	suite.commit = "commit"
+	suite.head = "head"
 	suite.host = "host"
 	suite.href = "href"
+	suite.kind = api.KindPullRequest
+	suite.lineEnd = 20
+	suite.lineStart = 10
+	suite.matchRule = api.MatchRuleDeclared
+	suite.namespace = "owner/subgroup"
+	suite.number = "1"
+	suite.org = "org"
 	suite.owner = "owner"
 	suite.path = "path"
+	suite.pathname = "/owner/repo.git"
+	suite.port = "port"
+	suite.prefix = "/prefix"
+	suite.profile = "profile"
+	suite.project = "project"
 	suite.protocol = "protocol"
 	suite.protocols = []string{"protocol"}
 	suite.provider = "provider"
+	suite.ref = api.Ref{Kind: api.RefKindTag, Name: "ref"}
+	suite.refAmbiguous = true
+	suite.region = "region"
 	suite.repo = "repo"
 	suite.resource = "resource"
+	suite.subgroups = []string{"subgroup"}
+	suite.user = "user"
 
 	suite.rm = &api.Repository{
-		Branch:   suite.branch,
-		Host:     suite.host,
-		HREF:     suite.href,
-		Owner:    suite.owner,
-		Path:     suite.path,
-		Protocol: suite.protocol,
-		Provider: suite.provider,
-		Repo:     suite.repo,
-		Resource: suite.resource,
+		Base:         suite.base,
+		Branch:       suite.branch,
+		Change:       suite.change,
+		Commit:       suite.commit,
+		Head:         suite.head,
+		Host:         suite.host,
+		HREF:         suite.href,
+		Kind:         suite.kind,
+		LineEnd:      suite.lineEnd,
+		LineStart:    suite.lineStart,
+		MatchRule:    suite.matchRule,
+		Namespace:    suite.namespace,
+		Number:       suite.number,
+		Organization: suite.org,
+		Owner:        suite.owner,
+		Path:         suite.path,
+		Pathname:     suite.pathname,
+		Port:         suite.port,
+		Prefix:       suite.prefix,
+		Profile:      suite.profile,
+		Project:      suite.project,
+		Protocol:     suite.protocol,
+		Provider:     suite.provider,
+		Ref:          suite.ref,
+		RefAmbiguous: suite.refAmbiguous,
+		Region:       suite.region,
+		Repo:         suite.repo,
+		Resource:     suite.resource,
+		Subgroups:    suite.subgroups,
+		User:         suite.user,
 	}
 }
 
+func (suite *APIPublicTestSuite) TestGetBaseOk() {
+	got := suite.rm.GetBase()
+
+	assert.Equal(suite.T(), suite.base, got)
+}
+
 func (suite *APIPublicTestSuite) TestGetBranchNameOk() {
 	got := suite.rm.GetBranchName()
 
 	assert.Equal(suite.T(), suite.branch, got)
 }
 
+func (suite *APIPublicTestSuite) TestGetChangeNumberOk() {
+	got := suite.rm.GetChangeNumber()
+
+	assert.Equal(suite.T(), suite.change, got)
+}
+
+func (suite *APIPublicTestSuite) TestGetCommitOk() {
+	got := suite.rm.GetCommit()
+
+	assert.Equal(suite.T(), suite.commit, got)
+}
+
+func (suite *APIPublicTestSuite) TestGetHeadOk() {
+	got := suite.rm.GetHead()
+
+	assert.Equal(suite.T(), suite.head, got)
+}
+
 func (suite *APIPublicTestSuite) TestGetHostNameOk() {
 	got := suite.rm.GetHostName()
 
@@ -90,6 +177,48 @@ func (suite *APIPublicTestSuite) TestGetHREFOk() {
 	assert.Equal(suite.T(), suite.href, got)
 }
 
+func (suite *APIPublicTestSuite) TestGetKindOk() {
+	got := suite.rm.GetKind()
+
+	assert.Equal(suite.T(), suite.kind, got)
+}
+
+func (suite *APIPublicTestSuite) TestGetLineEndOk() {
+	got := suite.rm.GetLineEnd()
+
+	assert.Equal(suite.T(), suite.lineEnd, got)
+}
+
+func (suite *APIPublicTestSuite) TestGetLineStartOk() {
+	got := suite.rm.GetLineStart()
+
+	assert.Equal(suite.T(), suite.lineStart, got)
+}
+
+func (suite *APIPublicTestSuite) TestGetMatchRuleOk() {
+	got := suite.rm.GetMatchRule()
+
+	assert.Equal(suite.T(), suite.matchRule, got)
+}
+
+func (suite *APIPublicTestSuite) TestGetNamespaceOk() {
+	got := suite.rm.GetNamespace()
+
+	assert.Equal(suite.T(), suite.namespace, got)
+}
+
+func (suite *APIPublicTestSuite) TestGetNumberOk() {
+	got := suite.rm.GetNumber()
+
+	assert.Equal(suite.T(), suite.number, got)
+}
+
+func (suite *APIPublicTestSuite) TestGetOrganizationNameOk() {
+	got := suite.rm.GetOrganizationName()
+
+	assert.Equal(suite.T(), suite.org, got)
+}
+
 func (suite *APIPublicTestSuite) TestGetOwnerNameOk() {
 	got := suite.rm.GetOwnerName()
 
@@ -102,6 +231,36 @@ func (suite *APIPublicTestSuite) TestGetPathOk() {
 	assert.Equal(suite.T(), suite.path, got)
 }
 
+func (suite *APIPublicTestSuite) TestGetPathnameOk() {
+	got := suite.rm.GetPathname()
+
+	assert.Equal(suite.T(), suite.pathname, got)
+}
+
+func (suite *APIPublicTestSuite) TestGetPortOk() {
+	got := suite.rm.GetPort()
+
+	assert.Equal(suite.T(), suite.port, got)
+}
+
+func (suite *APIPublicTestSuite) TestGetPrefixOk() {
+	got := suite.rm.GetPrefix()
+
+	assert.Equal(suite.T(), suite.prefix, got)
+}
+
+func (suite *APIPublicTestSuite) TestGetProfileNameOk() {
+	got := suite.rm.GetProfileName()
+
+	assert.Equal(suite.T(), suite.profile, got)
+}
+
+func (suite *APIPublicTestSuite) TestGetProjectNameOk() {
+	got := suite.rm.GetProjectName()
+
+	assert.Equal(suite.T(), suite.project, got)
+}
+
 func (suite *APIPublicTestSuite) TestGetProtocolOk() {
 	got := suite.rm.GetProtocol()
 
@@ -129,6 +288,24 @@ func (suite *APIPublicTestSuite) TestGetProviderNameOk() {
 	assert.Equal(suite.T(), suite.provider, got)
 }
 
+func (suite *APIPublicTestSuite) TestGetRefOk() {
+	got := suite.rm.GetRef()
+
+	assert.Equal(suite.T(), suite.ref, got)
+}
+
+func (suite *APIPublicTestSuite) TestGetRefAmbiguousOk() {
+	got := suite.rm.GetRefAmbiguous()
+
+	assert.Equal(suite.T(), suite.refAmbiguous, got)
+}
+
+func (suite *APIPublicTestSuite) TestGetRegionNameOk() {
+	got := suite.rm.GetRegionName()
+
+	assert.Equal(suite.T(), suite.region, got)
+}
+
 func (suite *APIPublicTestSuite) TestGetRepoNameOk() {
 	got := suite.rm.GetRepoName()
 
@@ -141,6 +318,18 @@ func (suite *APIPublicTestSuite) TestGetResourceNameOk() {
 	assert.Equal(suite.T(), suite.resource, got)
 }
 
+func (suite *APIPublicTestSuite) TestGetSubgroupsOk() {
+	got := suite.rm.GetSubgroups()
+
+	assert.Equal(suite.T(), suite.subgroups, got)
+}
+
+func (suite *APIPublicTestSuite) TestGetUserNameOk() {
+	got := suite.rm.GetUserName()
+
+	assert.Equal(suite.T(), suite.user, got)
+}
+
 // In order for `go test` to run this suite, we need to create
 // a normal test function and pass our suite to suite.Run.
 func TestAPIPublicTestSuite(t *testing.T) {
@@ -0,0 +1,148 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/retr0h/git-url-parse/pkg/giturl"
+)
+
+const (
+	defaultSSHUser string = "git"
+	gitSuffix      string = ".git"
+)
+
+// SetURLBuilder set the provider's builder used to format the repo's URLs.
+func (r *Repository) SetURLBuilder(builder URLBuilder) { r.builder = builder }
+
+// CloneURL format the repo's clone URL in the provided format, as in
+// `git@github.com:owner/repo.git` to `https://github.com/owner/repo.git`.
+// Providers implementing URLBuilder render their own layout, the rest use
+// DefaultRemote.  HTTPS URLs include the host's relative URL root.
+func (r *Repository) CloneURL(format CloneFormat) (string, error) {
+	var remote Remote
+	var err error
+	if r.builder != nil {
+		remote, err = r.builder.CloneRemote(r, format)
+	} else {
+		remote, err = r.DefaultRemote(format)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if format == CloneFormatHTTPS && r.Prefix != "" {
+		remote.Path = strings.TrimPrefix(r.Prefix, "/") + "/" + remote.Path
+	}
+
+	return formatRemote(format, remote)
+}
+
+// DefaultRemote return the parts of the repo's clone URL in the provided
+// format, following the `owner/repo.git` layout most providers share.  The
+// parsed user and port are kept when the format uses the same transport as
+// the parsed URL.
+func (r *Repository) DefaultRemote(format CloneFormat) (Remote, error) {
+	if r.Resource == "" || r.Repo == "" {
+		return Remote{}, fmt.Errorf("repo: %s has no host or name to format", r.HREF)
+	}
+
+	path := r.Repo + gitSuffix
+	if namespace := r.ownerPath(); namespace != "" {
+		path = namespace + "/" + path
+	}
+
+	remote := Remote{
+		Host: r.Resource,
+		Path: path,
+	}
+
+	switch format {
+	case CloneFormatSCP, CloneFormatSSH, CloneFormatGitSSH:
+		remote.User = defaultSSHUser
+		if r.isSSH() {
+			if r.User != "" {
+				remote.User = r.User
+			}
+			if format != CloneFormatSCP {
+				remote.Port = r.Port
+			}
+		}
+	case CloneFormatHTTPS, CloneFormatGit:
+		if r.Protocol == string(format) {
+			remote.Port = r.Port
+		}
+	default:
+		return Remote{}, fmt.Errorf("clone format: %s not supported", format)
+	}
+
+	return remote, nil
+}
+
+// ownerPath the path of the repo's owner, including any subgroups.
+func (r *Repository) ownerPath() string {
+	if r.Namespace != "" {
+		return r.Namespace
+	}
+
+	return r.Owner
+}
+
+// isSSH determine if the repo was parsed from a ssh or scp-like URL.
+func (r *Repository) isSSH() bool {
+	if !strings.Contains(r.HREF, "://") {
+		return true
+	}
+
+	for _, protocol := range r.GetProtocols() {
+		if protocol == "ssh" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// formatRemote render the remote as a URL in the provided format.
+func formatRemote(format CloneFormat, remote Remote) (string, error) {
+	user := ""
+	if remote.User != "" {
+		user = remote.User + "@"
+	}
+
+	host := giturl.JoinHost(remote.Host, remote.Port)
+
+	switch format {
+	case CloneFormatSCP:
+		if remote.Port != "" {
+			return "", fmt.Errorf("clone format: %s cannot name port: %s", format, remote.Port)
+		}
+
+		return user + giturl.JoinHost(remote.Host, "") + ":" + remote.Path, nil
+	case CloneFormatSSH, CloneFormatGitSSH:
+		return string(format) + "://" + user + host + "/" + remote.Path, nil
+	case CloneFormatHTTPS, CloneFormatGit:
+		return string(format) + "://" + host + "/" + remote.Path, nil
+	default:
+		return "", fmt.Errorf("clone format: %s not supported", format)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package api_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+type fakeBuilder struct{}
+
+func (fb *fakeBuilder) CloneRemote(
+	repo *api.Repository,
+	format api.CloneFormat,
+) (api.Remote, error) {
+	if format == api.CloneFormatGit {
+		return api.Remote{}, fmt.Errorf("clone format: %s not supported", format)
+	}
+
+	return api.Remote{
+		User: "builder",
+		Host: "builder.example.com",
+		Path: repo.Repo,
+	}, nil
+}
+
+type ClonePublicTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ClonePublicTestSuite) TestCloneURL() {
+	type test struct {
+		repo    *api.Repository
+		format  api.CloneFormat
+		want    string
+		wantErr bool
+	}
+
+	https := &api.Repository{
+		Protocol: "https",
+		Resource: "example.com",
+		Owner:    "owner",
+		Repo:     "repo",
+		HREF:     "https://example.com/owner/repo",
+	}
+	ssh := &api.Repository{
+		Protocol: "ssh",
+		Resource: "example.com",
+		Owner:    "owner",
+		Repo:     "repo",
+		User:     "deploy",
+		Port:     "2222",
+		HREF:     "ssh://deploy@example.com:2222/owner/repo.git",
+	}
+	subgroups := &api.Repository{
+		Protocol:  "git",
+		Resource:  "example.com",
+		Owner:     "group",
+		Namespace: "group/sub",
+		Repo:      "repo",
+		Prefix:    "/gitlab",
+		HREF:      "git@example.com:group/sub/repo.git",
+	}
+
+	tests := []test{
+		{
+			repo:   https,
+			format: api.CloneFormatSCP,
+			want:   "git@example.com:owner/repo.git",
+		},
+		{
+			repo:   https,
+			format: api.CloneFormatSSH,
+			want:   "ssh://git@example.com/owner/repo.git",
+		},
+		{
+			repo:   https,
+			format: api.CloneFormatHTTPS,
+			want:   "https://example.com/owner/repo.git",
+		},
+		{
+			repo:   https,
+			format: api.CloneFormatGit,
+			want:   "git://example.com/owner/repo.git",
+		},
+		{
+			repo:   https,
+			format: api.CloneFormatGitSSH,
+			want:   "git+ssh://git@example.com/owner/repo.git",
+		},
+		{
+			repo:   ssh,
+			format: api.CloneFormatSSH,
+			want:   "ssh://deploy@example.com:2222/owner/repo.git",
+		},
+		{
+			repo:   ssh,
+			format: api.CloneFormatSCP,
+			want:   "deploy@example.com:owner/repo.git",
+		},
+		{
+			repo:   ssh,
+			format: api.CloneFormatHTTPS,
+			want:   "https://example.com/owner/repo.git",
+		},
+		{
+			repo:   subgroups,
+			format: api.CloneFormatSCP,
+			want:   "git@example.com:group/sub/repo.git",
+		},
+		{
+			repo:   subgroups,
+			format: api.CloneFormatHTTPS,
+			want:   "https://example.com/gitlab/group/sub/repo.git",
+		},
+		// failure cases
+		{
+			repo:    https,
+			format:  api.CloneFormat("bogus"),
+			wantErr: true,
+		},
+		{
+			repo:    &api.Repository{Resource: "example.com"},
+			format:  api.CloneFormatHTTPS,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := tc.repo.CloneURL(tc.format)
+
+		if tc.wantErr {
+			assert.Error(suite.T(), err)
+		} else {
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tc.want, got)
+		}
+	}
+}
+
+func (suite *ClonePublicTestSuite) TestCloneURLWithURLBuilder() {
+	repo := &api.Repository{
+		Resource: "example.com",
+		Owner:    "owner",
+		Repo:     "repo",
+	}
+	repo.SetURLBuilder(&fakeBuilder{})
+
+	got, err := repo.CloneURL(api.CloneFormatSSH)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "ssh://builder@builder.example.com/repo", got)
+
+	_, err = repo.CloneURL(api.CloneFormatGit)
+	assert.Error(suite.T(), err)
+}
+
+// In order for `go test` to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run.
+func TestClonePublicTestSuite(t *testing.T) {
+	suite.Run(t, new(ClonePublicTestSuite))
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors each typed error matches with `errors.Is`, so callers can
+// tell failures apart without inspecting their messages.
+var (
+	// ErrUnsupportedHost no provider parses the URL's host.
+	ErrUnsupportedHost = errors.New("unsupported host")
+	// ErrNoMatch the provider selected for the URL's host matched none of
+	// its patterns.
+	ErrNoMatch = errors.New("no pattern matched")
+	// ErrMalformedURL the URL could not be tokenized.
+	ErrMalformedURL = errors.New("malformed url")
+	// ErrAmbiguousRef the URL does not mark where its ref ends and its path
+	// begins.
+	ErrAmbiguousRef = errors.New("ambiguous ref")
+	// ErrNotGit the URL names a repository of another version control
+	// system.
+	ErrNotGit = errors.New("not git")
+)
+
+// UnsupportedHostError no provider parses the URL's host.
+type UnsupportedHostError struct {
+	URL  string
+	Host string
+	// Providers the providers consulted, in lookup order.
+	Providers []string
+}
+
+// Error return the error's message.
+func (e *UnsupportedHostError) Error() string {
+	return fmt.Sprintf("could not find parser for host: %s", e.Host)
+}
+
+// Is match ErrUnsupportedHost.
+func (e *UnsupportedHostError) Is(target error) bool {
+	return target == ErrUnsupportedHost
+}
+
+// NoMatchError the provider selected for the URL's host matched none of its
+// patterns.
+type NoMatchError struct {
+	URL      string
+	Host     string
+	Provider string
+	// Patterns the regexps tried, in order.
+	Patterns []string
+}
+
+// Error return the error's message.
+func (e *NoMatchError) Error() string {
+	return fmt.Sprintf("could not match url: %s to any pattern", e.URL)
+}
+
+// Is match ErrNoMatch.
+func (e *NoMatchError) Is(target error) bool {
+	return target == ErrNoMatch
+}
+
+// MalformedURLError the URL could not be tokenized.  Err is the tokenizer's
+// error.
+type MalformedURLError struct {
+	URL string
+	Err error
+}
+
+// Error return the error's message.
+func (e *MalformedURLError) Error() string {
+	return e.Err.Error()
+}
+
+// Is match ErrMalformedURL.
+func (e *MalformedURLError) Is(target error) bool {
+	return target == ErrMalformedURL
+}
+
+// Unwrap return the tokenizer's error.
+func (e *MalformedURLError) Unwrap() error {
+	return e.Err
+}
+
+// AmbiguousRefError the URL does not mark where its ref ends and its path
+// begins, and no listed ref told them apart.
+type AmbiguousRefError struct {
+	URL      string
+	Host     string
+	Provider string
+	// Ref the ref split at the first `/`.
+	Ref Ref
+	// Path the path split at the first `/`.
+	Path string
+}
+
+// Error return the error's message.
+func (e *AmbiguousRefError) Error() string {
+	return fmt.Sprintf(
+		"url: %s does not mark where ref: %s ends and path: %s begins",
+		e.URL,
+		e.Ref.Name,
+		e.Path,
+	)
+}
+
+// Is match ErrAmbiguousRef.
+func (e *AmbiguousRefError) Is(target error) bool {
+	return target == ErrAmbiguousRef
+}
+
+// NotGitError the URL names a repository of another version control system.
+type NotGitError struct {
+	URL      string
+	Host     string
+	Provider string
+	// VCS the version control system of the repository, such as mercurial.
+	VCS string
+}
+
+// Error return the error's message.
+func (e *NotGitError) Error() string {
+	return fmt.Sprintf("url: %s is a %s repository, not git", e.URL, e.VCS)
+}
+
+// Is match ErrNotGit.
+func (e *NotGitError) Is(target error) bool {
+	return target == ErrNotGit
+}
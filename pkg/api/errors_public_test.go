@@ -0,0 +1,126 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package api_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+type ErrorsPublicTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ErrorsPublicTestSuite) TestIs() {
+	type test struct {
+		err  error
+		want error
+		msg  string
+	}
+
+	sentinels := []error{
+		api.ErrUnsupportedHost,
+		api.ErrNoMatch,
+		api.ErrMalformedURL,
+		api.ErrAmbiguousRef,
+		api.ErrNotGit,
+	}
+
+	tests := []test{
+		{
+			err:  &api.UnsupportedHostError{Host: "example.com"},
+			want: api.ErrUnsupportedHost,
+			msg:  "could not find parser for host: example.com",
+		},
+		{
+			err:  &api.NoMatchError{URL: "https://github.com/owner"},
+			want: api.ErrNoMatch,
+			msg:  "could not match url: https://github.com/owner to any pattern",
+		},
+		{
+			err:  &api.MalformedURLError{Err: errors.New("url: owner/repo is not a git remote")},
+			want: api.ErrMalformedURL,
+			msg:  "url: owner/repo is not a git remote",
+		},
+		{
+			err: &api.AmbiguousRefError{
+				URL:  "https://github.com/o/r/tree/feature/login",
+				Ref:  api.Ref{Name: "feature"},
+				Path: "login",
+			},
+			want: api.ErrAmbiguousRef,
+			msg:  "url: https://github.com/o/r/tree/feature/login does not mark where ref: feature ends and path: login begins",
+		},
+		{
+			err:  &api.NotGitError{URL: "https://hg.sr.ht/~user/repo", VCS: "mercurial"},
+			want: api.ErrNotGit,
+			msg:  "url: https://hg.sr.ht/~user/repo is a mercurial repository, not git",
+		},
+	}
+
+	for _, tc := range tests {
+		assert.EqualError(suite.T(), tc.err, tc.msg)
+
+		// wrapped errors still match
+		wrapped := fmt.Errorf("parse: %w", tc.err)
+		for _, sentinel := range sentinels {
+			assert.Equal(suite.T(), sentinel == tc.want, errors.Is(wrapped, sentinel), tc.msg)
+		}
+	}
+}
+
+func (suite *ErrorsPublicTestSuite) TestAs() {
+	err := fmt.Errorf("parse: %w", &api.NoMatchError{
+		URL:      "https://github.com/owner",
+		Host:     "github.com",
+		Provider: "github",
+		Patterns: []string{"^/(?P<owner>[^/]+)/(?P<repo>[^/]+)$"},
+	})
+
+	var nme *api.NoMatchError
+	assert.True(suite.T(), errors.As(err, &nme))
+	assert.Equal(suite.T(), "github.com", nme.Host)
+	assert.Equal(suite.T(), "github", nme.Provider)
+	assert.Len(suite.T(), nme.Patterns, 1)
+
+	// failure cases
+	var uhe *api.UnsupportedHostError
+	assert.False(suite.T(), errors.As(err, &uhe))
+}
+
+func (suite *ErrorsPublicTestSuite) TestMalformedURLErrorUnwrap() {
+	cause := errors.New("url: https://example.com:port/repo has an invalid port: port")
+	err := &api.MalformedURLError{URL: "https://example.com:port/repo", Err: cause}
+
+	assert.ErrorIs(suite.T(), err, cause)
+}
+
+// In order for `go test` to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run.
+func TestErrorsPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(ErrorsPublicTestSuite))
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package api_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+type RefPublicTestSuite struct {
+	suite.Suite
+}
+
+func (suite *RefPublicTestSuite) TestIsImmutable() {
+	type test struct {
+		ref  api.Ref
+		want bool
+	}
+
+	tests := []test{
+		{
+			ref:  api.Ref{Kind: api.RefKindCommit, Name: "0123abc"},
+			want: true,
+		},
+		// failure cases
+		{
+			ref:  api.Ref{Kind: api.RefKindBranch, Name: "main"},
+			want: false,
+		},
+		{
+			ref:  api.Ref{Kind: api.RefKindTag, Name: "v1.2.3"},
+			want: false,
+		},
+		{
+			ref:  api.Ref{Kind: api.RefKindUnknown, Name: "main"},
+			want: false,
+		},
+		{
+			ref:  api.Ref{},
+			want: false,
+		},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(suite.T(), tc.want, tc.ref.IsImmutable(), tc.ref)
+
+		repo := &api.Repository{Ref: tc.ref}
+		assert.Equal(suite.T(), tc.want, repo.IsImmutable(), tc.ref)
+	}
+}
+
+// In order for `go test` to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run.
+func TestRefPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(RefPublicTestSuite))
+}
@@ -20,15 +20,160 @@
 
 package api
 
+// RefKind the kind of git reference a URL points at.
+type RefKind string
+
+// Kinds of git reference.  RefKindUnknown when the URL does not say.
+const (
+	RefKindUnknown RefKind = ""
+	RefKindBranch  RefKind = "branch"
+	RefKindTag     RefKind = "tag"
+	RefKindCommit  RefKind = "commit"
+)
+
+// Ref struct containing a typed git reference.
+type Ref struct {
+	Kind RefKind
+	Name string
+}
+
+// Kind the kind of page a URL points at.
+type Kind string
+
+// Kinds of page.  KindUnknown when the provider does not classify its URLs.
+const (
+	KindUnknown     Kind = ""
+	KindRepo        Kind = "repo"
+	KindTree        Kind = "tree"
+	KindBlob        Kind = "blob"
+	KindRaw         Kind = "raw"
+	KindBlame       Kind = "blame"
+	KindHistory     Kind = "history"
+	KindCommit      Kind = "commit"
+	KindPullRequest Kind = "pull_request"
+	KindIssue       Kind = "issue"
+	KindCompare     Kind = "compare"
+	KindRelease     Kind = "release"
+	KindTag         Kind = "tag"
+	// KindRun a CI run, as a GitHub Actions run or a GitLab pipeline.
+	KindRun  Kind = "run"
+	KindWiki Kind = "wiki"
+)
+
+// MatchRule the rule which selected the provider for a URL's host.
+type MatchRule string
+
+// Rules which select a provider, in the order they are consulted.
+const (
+	MatchRuleDeclared  MatchRule = "declared"
+	MatchRuleProvider  MatchRule = "provider"
+	MatchRuleHeuristic MatchRule = "heuristic"
+	MatchRuleFallback  MatchRule = "fallback"
+)
+
+// CloneFormat the flavor of a clone URL.
+type CloneFormat string
+
+// Flavors of clone URL.
+const (
+	// CloneFormatSCP as in `git@host:owner/repo.git`.
+	CloneFormatSCP CloneFormat = "scp"
+	// CloneFormatSSH as in `ssh://git@host/owner/repo.git`.
+	CloneFormatSSH CloneFormat = "ssh"
+	// CloneFormatHTTPS as in `https://host/owner/repo.git`.
+	CloneFormatHTTPS CloneFormat = "https"
+	// CloneFormatGit as in `git://host/owner/repo.git`.
+	CloneFormatGit CloneFormat = "git"
+	// CloneFormatGitSSH as in `git+ssh://git@host/owner/repo.git`.
+	CloneFormatGitSSH CloneFormat = "git+ssh"
+)
+
+// Remote struct containing the parts of a clone URL.
+type Remote struct {
+	User string
+	Host string
+	Port string
+	// Path the path following the host, without a leading `/`.
+	Path string
+}
+
+// URLBuilder optional interface implemented by a provider whose clone URLs do
+// not follow the `owner/repo.git` layout of Repository.DefaultRemote.
+type URLBuilder interface {
+	// CloneRemote return the parts of the repo's clone URL in the provided
+	// format, or an error when the provider does not serve it.
+	CloneRemote(repo *Repository, format CloneFormat) (Remote, error)
+}
+
+// View the kind of web page a WebURL links to.
+type View string
+
+// Kinds of web page.
+const (
+	ViewTree    View = "tree"
+	ViewBlob    View = "blob"
+	ViewRaw     View = "raw"
+	ViewBlame   View = "blame"
+	ViewHistory View = "history"
+	ViewCommit  View = "commit"
+)
+
+// Location struct containing the ref, path and lines a WebURL links to.
+// Commit views link to the commit named by the ref, and ignore the path.
+// RefKind, as parsed into the repo's Ref, is needed by providers whose URLs
+// name the kind of ref; unknown refs link as commits when a full commit hash,
+// and as branches otherwise.  Path is decoded, as parsed, and escaped when
+// linked.  Lines are anchored in blob and blame views only; a zero LineEnd
+// names the single line LineStart.
+type Location struct {
+	Ref       string
+	RefKind   RefKind
+	Path      string
+	LineStart int
+	LineEnd   int
+}
+
+// WebURLBuilder optional interface implemented by a provider able to link
+// to its web pages.
+type WebURLBuilder interface {
+	// WebURL return the URL of the repo's web page for the provided view
+	// and location, or an error when the provider has no such page.
+	WebURL(repo *Repository, view View, location Location) (string, error)
+}
+
 // Repository struct containing parsed URL fields.
 type Repository struct {
-	Branch   string
-	Host     string
-	HREF     string
-	Owner    string
-	Path     string
-	Protocol string
-	Provider string
-	Repo     string
-	Resource string
+	Base         string
+	Branch       string
+	Change       string
+	Commit       string
+	Head         string
+	Host         string
+	HREF         string
+	Kind         Kind
+	LineEnd      int
+	LineStart    int
+	MatchRule    MatchRule
+	Namespace    string
+	Number       string
+	Organization string
+	Owner        string
+	Path         string
+	Pathname     string
+	Port         string
+	Prefix       string
+	Profile      string
+	Project      string
+	Protocol     string
+	Provider     string
+	Ref          Ref
+	RefAmbiguous bool
+	Region       string
+	Repo         string
+	Resource     string
+	Subgroups    []string
+	User         string
+
+	builder    URLBuilder
+	webBuilder WebURLBuilder
 }
@@ -0,0 +1,53 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package api
+
+import (
+	"fmt"
+)
+
+// SetWebURLBuilder set the provider's builder used to link to the repo's web
+// pages.
+func (r *Repository) SetWebURLBuilder(builder WebURLBuilder) { r.webBuilder = builder }
+
+// WebURL return the URL of the repo's web page for the provided view and
+// location, as in `https://github.com/owner/repo/blob/main/README.md`.
+func (r *Repository) WebURL(view View, location Location) (string, error) {
+	if r.webBuilder == nil {
+		return "", fmt.Errorf("provider: %s does not build web urls", r.Provider)
+	}
+
+	if location.Ref == "" {
+		return "", fmt.Errorf("view: %s requires a ref", view)
+	}
+
+	switch view {
+	case ViewBlob, ViewRaw, ViewBlame:
+		if location.Path == "" {
+			return "", fmt.Errorf("view: %s requires a path", view)
+		}
+	}
+
+	if location.LineStart < 0 || (location.LineEnd != 0 && location.LineEnd < location.LineStart) {
+		return "", fmt.Errorf("lines: %d-%d are not a valid range", location.LineStart, location.LineEnd)
+	}
+
+	return r.webBuilder.WebURL(r, view, location)
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package api_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+type fakeWebBuilder struct{}
+
+func (fb *fakeWebBuilder) WebURL(
+	repo *api.Repository,
+	view api.View,
+	location api.Location,
+) (string, error) {
+	if view == api.ViewBlame {
+		return "", fmt.Errorf("view: %s not supported", view)
+	}
+
+	return fmt.Sprintf("https://%s/%s/%s/%s", repo.Resource, view, location.Ref, location.Path), nil
+}
+
+type WebPublicTestSuite struct {
+	suite.Suite
+}
+
+func (suite *WebPublicTestSuite) TestWebURL() {
+	type test struct {
+		view     api.View
+		location api.Location
+		want     string
+		wantErr  bool
+	}
+
+	repo := &api.Repository{Resource: "example.com"}
+	repo.SetWebURLBuilder(&fakeWebBuilder{})
+
+	tests := []test{
+		{
+			view:     api.ViewBlob,
+			location: api.Location{Ref: "main", Path: "README.md"},
+			want:     "https://example.com/blob/main/README.md",
+			wantErr:  false,
+		},
+		{
+			view:     api.ViewTree,
+			location: api.Location{Ref: "main"},
+			want:     "https://example.com/tree/main/",
+			wantErr:  false,
+		},
+		// failure cases
+		{
+			view:     api.ViewTree,
+			location: api.Location{Path: "README.md"},
+			wantErr:  true,
+		},
+		{
+			view:     api.ViewRaw,
+			location: api.Location{Ref: "main"},
+			wantErr:  true,
+		},
+		{
+			view:     api.ViewBlame,
+			location: api.Location{Ref: "main", Path: "README.md"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := repo.WebURL(tc.view, tc.location)
+
+		if tc.wantErr {
+			assert.Error(suite.T(), err)
+		} else {
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tc.want, got)
+		}
+	}
+}
+
+func (suite *WebPublicTestSuite) TestWebURLWithoutWebURLBuilder() {
+	repo := &api.Repository{Provider: "generic", Resource: "example.com"}
+
+	_, err := repo.WebURL(api.ViewTree, api.Location{Ref: "main"})
+	assert.Error(suite.T(), err)
+}
+
+// In order for `go test` to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run.
+func TestWebPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(WebPublicTestSuite))
+}
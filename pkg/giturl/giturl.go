@@ -0,0 +1,190 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package giturl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// scpScheme the scheme git uses for scp-like URLs.
+const scpScheme string = "ssh"
+
+// Parse split the provided git remote into its parts.  Remotes are read as:
+//
+//  1. `<helper>::<address>`, a remote helper and the address handed to it.
+//  2. `<scheme>://[user[:password]@]host[:port][/path][?query][#fragment]`.
+//  3. `[user@]host:path`, the scp-like syntax, where a colon precedes any
+//     slash.
+//
+// Local paths, and remotes naming no host, are rejected.
+func Parse(raw string) (*URL, error) {
+	if helper, address, ok := strings.Cut(raw, "::"); ok && isScheme(helper) {
+		u, err := Parse(address)
+		if err != nil {
+			return nil, err
+		}
+		u.Helper = helper
+
+		return u, nil
+	}
+
+	if scheme, rest, ok := strings.Cut(raw, "://"); ok && isScheme(scheme) {
+		return parseURL(raw, scheme, rest)
+	}
+
+	return parseSCPLike(raw)
+}
+
+// parseURL split the part of a URL following its scheme into its parts.
+func parseURL(raw string, scheme string, rest string) (*URL, error) {
+	u := &URL{Scheme: strings.ToLower(scheme)}
+
+	rest, u.Fragment, _ = strings.Cut(rest, "#")
+	rest, u.Query, _ = strings.Cut(rest, "?")
+
+	authority := rest
+	if i := strings.Index(rest, "/"); i != -1 {
+		authority, u.Path = rest[:i], rest[i:]
+	}
+
+	if i := strings.LastIndex(authority, "@"); i != -1 {
+		u.User, u.Password, _ = strings.Cut(authority[:i], ":")
+		authority = authority[i+1:]
+	}
+
+	host, port, err := splitHostPort(authority)
+	if err != nil {
+		return nil, fmt.Errorf("url: %s %w", raw, err)
+	}
+	if host == "" {
+		return nil, fmt.Errorf("url: %s names no host", raw)
+	}
+	u.Host, u.Port = strings.ToLower(host), port
+
+	return u, nil
+}
+
+// parseSCPLike split a scp-like URL into its parts.  URLs with a slash
+// before the first colon are local paths.
+func parseSCPLike(raw string) (*URL, error) {
+	u := &URL{Scheme: scpScheme, SCPLike: true}
+
+	rest, fragment, _ := strings.Cut(raw, "#")
+	rest, query, _ := strings.Cut(rest, "?")
+
+	authority := rest
+	if i := strings.LastIndex(rest[:scpColon(rest)], "@"); i != -1 {
+		u.User, authority = rest[:i], rest[i+1:]
+	}
+
+	colon := scpColon(authority)
+	if colon == len(authority) || strings.Contains(authority[:colon], "/") {
+		return nil, fmt.Errorf("url: %s is not a git remote", raw)
+	}
+
+	host := strings.TrimSuffix(strings.TrimPrefix(authority[:colon], "["), "]")
+	if host == "" {
+		return nil, fmt.Errorf("url: %s names no host", raw)
+	}
+	u.Host, u.Path, u.Query, u.Fragment = strings.ToLower(host), authority[colon+1:], query, fragment
+
+	return u, nil
+}
+
+// scpColon return the index of the colon ending a scp-like URL's host,
+// skipping over a bracketed IPv6 address, or the length of the URL when
+// there is none.
+func scpColon(url string) int {
+	start := 0
+	if i := strings.Index(url, "["); i != -1 && !strings.Contains(url[:i], ":") {
+		if j := strings.Index(url[i:], "]"); j != -1 {
+			start = i + j
+		}
+	}
+
+	i := strings.Index(url[start:], ":")
+	if i == -1 {
+		return len(url)
+	}
+
+	return start + i
+}
+
+// splitHostPort split an authority's host and port, as in `host:22` or
+// `[::1]:22`.  Ports must be decimal.
+func splitHostPort(hostport string) (string, string, error) {
+	host, port := hostport, ""
+	if strings.HasPrefix(hostport, "[") {
+		end := strings.Index(hostport, "]")
+		if end == -1 {
+			return "", "", fmt.Errorf("has an unclosed IPv6 address")
+		}
+
+		host, port = hostport[1:end], hostport[end+1:]
+		if port != "" && !strings.HasPrefix(port, ":") {
+			return "", "", fmt.Errorf("has an invalid port: %s", port)
+		}
+		port = strings.TrimPrefix(port, ":")
+	} else if i := strings.LastIndex(hostport, ":"); i != -1 {
+		host, port = hostport[:i], hostport[i+1:]
+	}
+
+	for _, c := range port {
+		if c < '0' || c > '9' {
+			return "", "", fmt.Errorf("has an invalid port: %s", port)
+		}
+	}
+
+	return host, port, nil
+}
+
+// JoinHost format a URL's host and optional port, putting back the brackets
+// of IPv6 addresses, which `Parse` strips.
+func JoinHost(host string, port string) string {
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if port != "" {
+		host += ":" + port
+	}
+
+	return host
+}
+
+// isScheme determine if the provided name is a valid URL scheme, or remote
+// helper name; a letter followed by letters, digits, `+`, `-` or `.`.
+func isScheme(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case i > 0 && (c >= '0' && c <= '9' || c == '+' || c == '-' || c == '.'):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package giturl
+
+// URL struct containing the parts of a git remote.  Parts are kept as
+// written, without decoding.
+type URL struct {
+	// Helper the remote helper of a `<helper>::<address>` URL, as
+	// `codecommit` in `codecommit::us-east-1://profile@repo`; the remaining
+	// parts are read from the address.
+	Helper string
+	// Scheme the URL's scheme, lowercased, as in `https` or `git+ssh`.
+	// Scp-like URLs name none, and are reported as `ssh`.
+	Scheme   string
+	User     string
+	Password string
+	// Host the URL's host, lowercased, without the brackets of an IPv6
+	// address.
+	Host string
+	Port string
+	// Path the path following the host and port; scp-like paths follow the
+	// colon, and have no leading `/` unless written.
+	Path     string
+	Query    string
+	Fragment string
+	// SCPLike true for scp-like URLs, as in `git@github.com:owner/repo.git`.
+	SCPLike bool
+}
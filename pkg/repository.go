@@ -20,16 +20,75 @@
 
 package pkg
 
+import (
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+// ParserManager manager responsible for each provider's parsing operations.
+// Implement to plug additional providers into a repository.Registry.
+type ParserManager interface {
+	// ShouldParse determine if the provider handles the provided host.
+	ShouldParse(host string) bool
+	// Parse the provided URL.
+	Parse(url string) (*api.Repository, error)
+}
+
+// HeuristicMatcher optional interface implemented by a ParserManager able to
+// recognize self-hosted instances from their host name alone.  Heuristics are
+// only consulted once enabled with `repository.Registry.EnableHeuristics`.
+type HeuristicMatcher interface {
+	MatchHeuristic(host string) bool
+}
+
+// FallbackMatcher optional interface implemented by a ParserManager able to
+// parse URLs of any host.  Fallbacks are only consulted once every other
+// rule fails.
+type FallbackMatcher interface {
+	MatchFallback(host string) bool
+}
+
+// RefResolver resolver responsible for listing the refs known to a repo, as
+// read from `git ls-remote` or a local bare repo.  Used with
+// `repository.Parser.WithRefResolver` to split a URL's ref from its path when
+// the ref contains `/`.
+type RefResolver interface {
+	ResolveRefs(repo *api.Repository) ([]api.Ref, error)
+}
+
 // RepositoryManager manager responsible for get Repository operations.
 type RepositoryManager interface {
+	CloneURL(format api.CloneFormat) (string, error)
+	WebURL(view api.View, location api.Location) (string, error)
+	GetBase() string
 	GetBranchName() string
+	GetChangeNumber() string
+	GetCommit() string
+	GetHead() string
 	GetHREF() string
 	GetHostName() string
+	GetKind() api.Kind
+	GetLineEnd() int
+	GetLineStart() int
+	GetMatchRule() api.MatchRule
+	GetNamespace() string
+	GetNumber() string
+	GetOrganizationName() string
 	GetOwnerName() string
 	GetPath() string
+	GetPathname() string
+	GetPort() string
+	GetPrefix() string
+	GetProfileName() string
+	GetProjectName() string
 	GetProtocol() string
 	GetProtocols() []string
 	GetProviderName() string
+	GetRef() api.Ref
+	GetRefAmbiguous() bool
+	GetRegionName() string
 	GetRepoName() string
 	GetResourceName() string
+	GetSubgroups() []string
+	GetUserName() string
+	IsImmutable() bool
 }
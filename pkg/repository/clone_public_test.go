@@ -0,0 +1,451 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package repository_test
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/retr0h/git-url-parse/pkg/api"
+	"github.com/retr0h/git-url-parse/pkg/repository"
+)
+
+type ClonePublicTestSuite struct {
+	suite.Suite
+
+	p *repository.Parser
+
+	logger *slog.Logger
+}
+
+func (suite *ClonePublicTestSuite) SetupTest() {
+	suite.logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	suite.p = repository.NewParser(suite.logger)
+	err := suite.p.Registry().RegisterHost(repository.Host{
+		Name:     "bitbucket.example.com",
+		Provider: "bitbucket-server",
+	})
+	require.NoError(suite.T(), err)
+}
+
+func (suite *ClonePublicTestSuite) TestCloneURL() {
+	type test struct {
+		input   string
+		format  api.CloneFormat
+		want    string
+		wantErr bool
+	}
+
+	tests := []test{
+		{
+			input:  "git@github.com:owner/repo.git",
+			format: api.CloneFormatSCP,
+			want:   "git@github.com:owner/repo.git",
+		},
+		{
+			input:  "git@github.com:owner/repo.git",
+			format: api.CloneFormatSSH,
+			want:   "ssh://git@github.com/owner/repo.git",
+		},
+		{
+			input:  "git@github.com:owner/repo.git",
+			format: api.CloneFormatHTTPS,
+			want:   "https://github.com/owner/repo.git",
+		},
+		{
+			input:  "git@github.com:owner/repo.git",
+			format: api.CloneFormatGitSSH,
+			want:   "git+ssh://git@github.com/owner/repo.git",
+		},
+		{
+			input:  "https://github.com/owner/repo/blob/main/README.md",
+			format: api.CloneFormatSCP,
+			want:   "git@github.com:owner/repo.git",
+		},
+		{
+			input:  "https://github.com/owner/repo/blob/main/README.md",
+			format: api.CloneFormatHTTPS,
+			want:   "https://github.com/owner/repo.git",
+		},
+		{
+			input:  "https://raw.githubusercontent.com/owner/repo/main/README.md",
+			format: api.CloneFormatSCP,
+			want:   "git@github.com:owner/repo.git",
+		},
+		{
+			input:  "https://raw.githubusercontent.com/owner/repo/main/README.md",
+			format: api.CloneFormatHTTPS,
+			want:   "https://github.com/owner/repo.git",
+		},
+		{
+			input:  "https://www.github.com/owner/repo",
+			format: api.CloneFormatSCP,
+			want:   "git@github.com:owner/repo.git",
+		},
+		{
+			input:  "https://gitlab.com/group/sub/repo.git",
+			format: api.CloneFormatSCP,
+			want:   "git@gitlab.com:group/sub/repo.git",
+		},
+		{
+			input:  "https://gitlab.com/group/sub/repo.git",
+			format: api.CloneFormatSSH,
+			want:   "ssh://git@gitlab.com/group/sub/repo.git",
+		},
+		{
+			input:  "https://gitlab.com/group/sub/repo.git",
+			format: api.CloneFormatHTTPS,
+			want:   "https://gitlab.com/group/sub/repo.git",
+		},
+		{
+			input:  "https://gitlab.com/group/sub/repo.git",
+			format: api.CloneFormatGitSSH,
+			want:   "git+ssh://git@gitlab.com/group/sub/repo.git",
+		},
+		{
+			input:  "ssh://deploy@gitlab.com:2222/owner/repo.git",
+			format: api.CloneFormatSSH,
+			want:   "ssh://deploy@gitlab.com:2222/owner/repo.git",
+		},
+		{
+			input:  "ssh://deploy@gitlab.com:2222/owner/repo.git",
+			format: api.CloneFormatSCP,
+			want:   "deploy@gitlab.com:owner/repo.git",
+		},
+		{
+			input:  "ssh://deploy@gitlab.com:2222/owner/repo.git",
+			format: api.CloneFormatHTTPS,
+			want:   "https://gitlab.com/owner/repo.git",
+		},
+		{
+			input:  "https://bitbucket.org/owner/repo",
+			format: api.CloneFormatSCP,
+			want:   "git@bitbucket.org:owner/repo.git",
+		},
+		{
+			input:  "https://bitbucket.org/owner/repo",
+			format: api.CloneFormatSSH,
+			want:   "ssh://git@bitbucket.org/owner/repo.git",
+		},
+		{
+			input:  "https://bitbucket.org/owner/repo",
+			format: api.CloneFormatHTTPS,
+			want:   "https://bitbucket.org/owner/repo.git",
+		},
+		{
+			input:  "https://dev.azure.com/org/project/_git/repo",
+			format: api.CloneFormatSCP,
+			want:   "git@ssh.dev.azure.com:v3/org/project/repo",
+		},
+		{
+			input:  "https://dev.azure.com/org/project/_git/repo",
+			format: api.CloneFormatSSH,
+			want:   "ssh://git@ssh.dev.azure.com/v3/org/project/repo",
+		},
+		{
+			input:  "https://dev.azure.com/org/project/_git/repo",
+			format: api.CloneFormatHTTPS,
+			want:   "https://dev.azure.com/org/project/_git/repo",
+		},
+		{
+			input:  "git@ssh.dev.azure.com:v3/org/project/repo",
+			format: api.CloneFormatHTTPS,
+			want:   "https://dev.azure.com/org/project/_git/repo",
+		},
+		{
+			input:  "git@ssh.dev.azure.com:v3/org/project/repo",
+			format: api.CloneFormatGitSSH,
+			want:   "git+ssh://git@ssh.dev.azure.com/v3/org/project/repo",
+		},
+		{
+			input:  "https://org.visualstudio.com/project/_git/repo",
+			format: api.CloneFormatHTTPS,
+			want:   "https://org.visualstudio.com/project/_git/repo",
+		},
+		{
+			input:  "https://org.visualstudio.com/project/_git/repo",
+			format: api.CloneFormatSCP,
+			want:   "git@ssh.dev.azure.com:v3/org/project/repo",
+		},
+		{
+			input:  "https://codeberg.org/owner/repo",
+			format: api.CloneFormatSCP,
+			want:   "git@codeberg.org:owner/repo.git",
+		},
+		{
+			input:  "https://codeberg.org/owner/repo",
+			format: api.CloneFormatHTTPS,
+			want:   "https://codeberg.org/owner/repo.git",
+		},
+		{
+			input:  "https://review.opendev.org/c/openstack/nova/+/12345",
+			format: api.CloneFormatSSH,
+			want:   "ssh://git@review.opendev.org:29418/openstack/nova.git",
+		},
+		{
+			input:  "https://review.opendev.org/c/openstack/nova/+/12345",
+			format: api.CloneFormatHTTPS,
+			want:   "https://review.opendev.org/openstack/nova.git",
+		},
+		{
+			input:  "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/repo",
+			format: api.CloneFormatHTTPS,
+			want:   "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/repo",
+		},
+		{
+			input:  "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/repo",
+			format: api.CloneFormatSSH,
+			want:   "ssh://git-codecommit.us-east-1.amazonaws.com/v1/repos/repo",
+		},
+		{
+			input:  "codecommit::us-east-1://repo",
+			format: api.CloneFormatHTTPS,
+			want:   "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/repo",
+		},
+		{
+			input:  "https://go.googlesource.com/go/+/refs/heads/master/README.md",
+			format: api.CloneFormatHTTPS,
+			want:   "https://go.googlesource.com/go",
+		},
+		{
+			input:  "git@git.sr.ht:~owner/repo",
+			format: api.CloneFormatHTTPS,
+			want:   "https://git.sr.ht/~owner/repo",
+		},
+		{
+			input:  "git@git.sr.ht:~owner/repo",
+			format: api.CloneFormatSSH,
+			want:   "ssh://git@git.sr.ht/~owner/repo",
+		},
+		{
+			input:  "rsync://example.com/owner/repo.git",
+			format: api.CloneFormatSCP,
+			want:   "git@example.com:owner/repo.git",
+		},
+		{
+			input:  "rsync://example.com/owner/repo.git",
+			format: api.CloneFormatHTTPS,
+			want:   "https://example.com/owner/repo.git",
+		},
+		{
+			input:  "rsync://example.com/owner/repo.git",
+			format: api.CloneFormatGit,
+			want:   "git://example.com/owner/repo.git",
+		},
+		{
+			input:  "https://bitbucket.example.com/scm/proj/repo.git",
+			format: api.CloneFormatSSH,
+			want:   "ssh://git@bitbucket.example.com:7999/proj/repo.git",
+		},
+		{
+			input:  "ssh://git@bitbucket.example.com:7999/proj/repo.git",
+			format: api.CloneFormatHTTPS,
+			want:   "https://bitbucket.example.com/scm/proj/repo.git",
+		},
+		{
+			input:  "https://bitbucket.example.com/users/~jdoe/repos/repo/browse",
+			format: api.CloneFormatSSH,
+			want:   "ssh://git@bitbucket.example.com:7999/~jdoe/repo.git",
+		},
+		{
+			input:  "ssh://git@[::1]:2222/owner/repo.git",
+			format: api.CloneFormatSSH,
+			want:   "ssh://git@[::1]:2222/owner/repo.git",
+		},
+		{
+			input:  "git@[::1]:owner/repo.git",
+			format: api.CloneFormatSCP,
+			want:   "git@[::1]:owner/repo.git",
+		},
+		{
+			input:  "https://[2001:db8::1]:8443/owner/repo.git",
+			format: api.CloneFormatHTTPS,
+			want:   "https://[2001:db8::1]:8443/owner/repo.git",
+		},
+		// failure cases
+		{
+			input:   "ssh://git@bitbucket.example.com:7999/proj/repo.git",
+			format:  api.CloneFormatSCP,
+			wantErr: true,
+		},
+		{
+			input:   "ssh://git@bitbucket.example.com:7999/proj/repo.git",
+			format:  api.CloneFormatGit,
+			wantErr: true,
+		},
+		{
+			input:   "git@github.com:owner/repo.git",
+			format:  api.CloneFormatGit,
+			wantErr: true,
+		},
+		{
+			input:   "https://gitlab.com/group/sub/repo.git",
+			format:  api.CloneFormatGit,
+			wantErr: true,
+		},
+		{
+			input:   "https://bitbucket.org/owner/repo",
+			format:  api.CloneFormatGit,
+			wantErr: true,
+		},
+		{
+			input:   "https://codeberg.org/owner/repo",
+			format:  api.CloneFormatGit,
+			wantErr: true,
+		},
+		{
+			input:   "https://review.opendev.org/openstack/nova",
+			format:  api.CloneFormatSCP,
+			wantErr: true,
+		},
+		{
+			input:   "https://go.googlesource.com/go",
+			format:  api.CloneFormatSSH,
+			wantErr: true,
+		},
+		{
+			input:   "git@git.sr.ht:~owner/repo",
+			format:  api.CloneFormatGit,
+			wantErr: true,
+		},
+		{
+			input:   "https://dev.azure.com/org/project/_git/repo",
+			format:  api.CloneFormatGit,
+			wantErr: true,
+		},
+		{
+			input:   "codecommit::us-east-1://repo",
+			format:  api.CloneFormatSCP,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		repo, err := suite.p.Parse(tc.input)
+		require.NoError(suite.T(), err)
+
+		got, err := repo.CloneURL(tc.format)
+
+		if tc.wantErr {
+			assert.Error(suite.T(), err, tc.input)
+		} else {
+			require.NoError(suite.T(), err, tc.input)
+			assert.Equal(suite.T(), tc.want, got, tc.input)
+		}
+	}
+}
+
+func (suite *ClonePublicTestSuite) TestCloneURLWithHostPrefix() {
+	err := suite.p.Registry().RegisterHost(repository.Host{
+		Name:     "example.com",
+		Provider: "gitlab",
+		Prefix:   "/gitlab",
+	})
+	require.NoError(suite.T(), err)
+
+	repo, err := suite.p.Parse("https://example.com/gitlab/group/repo/-/blob/main/x")
+	require.NoError(suite.T(), err)
+
+	got, err := repo.CloneURL(api.CloneFormatHTTPS)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "https://example.com/gitlab/group/repo.git", got)
+
+	got, err = repo.CloneURL(api.CloneFormatSCP)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "git@example.com:group/repo.git", got)
+}
+
+// TestCloneURLRoundTrip proves Parse, CloneURL and Parse again yield the same
+// repo, and formatting it again yields the same URL.  Hosts may differ per
+// transport, as in Azure's `ssh.dev.azure.com`, so repos are compared by
+// their HTTPS clone URL.
+func (suite *ClonePublicTestSuite) TestCloneURLRoundTrip() {
+	inputs := []string{
+		"git@github.com:owner/repo.git",
+		"https://raw.githubusercontent.com/owner/repo/main/README.md",
+		"https://www.github.com/owner/repo",
+		"https://gitlab.com/group/sub/repo.git",
+		"https://bitbucket.org/owner/repo",
+		"https://dev.azure.com/org/project/_git/repo",
+		"https://codeberg.org/owner/repo",
+		"https://review.opendev.org/openstack/nova",
+		"https://git-codecommit.us-east-1.amazonaws.com/v1/repos/repo",
+		"https://go.googlesource.com/go",
+		"https://git.sr.ht/~owner/repo",
+		"https://bitbucket.example.com/scm/proj/repo.git",
+		"ssh://user@example.com:29418/owner/repo.git",
+		"ssh://git@[::1]:2222/owner/repo.git",
+		"git@[::1]:owner/repo.git",
+		"https://[2001:db8::1]/owner/repo.git",
+	}
+
+	formats := []api.CloneFormat{
+		api.CloneFormatSCP,
+		api.CloneFormatSSH,
+		api.CloneFormatHTTPS,
+		api.CloneFormatGit,
+		api.CloneFormatGitSSH,
+	}
+
+	for _, input := range inputs {
+		want, err := suite.p.Parse(input)
+		require.NoError(suite.T(), err)
+
+		for _, format := range formats {
+			url, err := want.CloneURL(format)
+			if err != nil {
+				// formats the provider does not serve
+				continue
+			}
+
+			got, err := suite.p.Parse(url)
+			require.NoError(suite.T(), err, url)
+
+			assert.Equal(suite.T(), want.GetProviderName(), got.GetProviderName(), url)
+			assert.Equal(suite.T(), want.GetOwnerName(), got.GetOwnerName(), url)
+			assert.Equal(suite.T(), want.GetNamespace(), got.GetNamespace(), url)
+			assert.Equal(suite.T(), want.GetOrganizationName(), got.GetOrganizationName(), url)
+			assert.Equal(suite.T(), want.GetProjectName(), got.GetProjectName(), url)
+			assert.Equal(suite.T(), want.GetRepoName(), got.GetRepoName(), url)
+
+			wantHTTPS, err := want.CloneURL(api.CloneFormatHTTPS)
+			require.NoError(suite.T(), err)
+			gotHTTPS, err := got.CloneURL(api.CloneFormatHTTPS)
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), wantHTTPS, gotHTTPS, url)
+
+			again, err := got.CloneURL(format)
+			require.NoError(suite.T(), err, url)
+			assert.Equal(suite.T(), url, again)
+		}
+	}
+}
+
+// In order for `go test` to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run.
+func TestClonePublicTestSuite(t *testing.T) {
+	suite.Run(t, new(ClonePublicTestSuite))
+}
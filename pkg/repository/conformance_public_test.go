@@ -0,0 +1,693 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package repository_test
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/retr0h/git-url-parse/pkg"
+	"github.com/retr0h/git-url-parse/pkg/repository"
+)
+
+// Reasons the Go port intentionally diverges from the Python giturlparse
+// fixtures in `python/test/conftest.py`.
+const (
+	reasonMultiProtocol = "Go reports the full scheme as the protocol, as in `git+ssh`; GetProtocols splits it"
+	reasonSCPProtocols  = "Go derives the protocols from the protocol, and scp-like URLs are ssh"
+	reasonSCPPort       = "git's url.c reads scp-like URLs as `host:path`, so `9999/` is part of the path, not a port"
+	reasonSchemeless    = "Go requires a scheme or scp-like syntax to find the host"
+)
+
+// divergence a field where Go intentionally reports a different value than
+// the Python fixture.
+type divergence struct {
+	field  string
+	want   any
+	reason string
+}
+
+type ConformancePublicTestSuite struct {
+	suite.Suite
+
+	p *repository.Parser
+
+	logger *slog.Logger
+}
+
+func (suite *ConformancePublicTestSuite) SetupTest() {
+	suite.logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	suite.p = repository.NewParser(suite.logger)
+}
+
+// fields the Go values of the fields asserted by the Python fixtures, keyed
+// by the Python name.  Python's None is ported as "".
+func fields(got pkg.RepositoryManager) map[string]any {
+	return map[string]any{
+		"pathname":  got.GetPathname(),
+		"protocols": got.GetProtocols(),
+		"protocol":  got.GetProtocol(),
+		"href":      got.GetHREF(),
+		"resource":  got.GetResourceName(),
+		"user":      got.GetUserName(),
+		"port":      got.GetPort(),
+		"name":      got.GetRepoName(),
+		"owner":     got.GetOwnerName(),
+	}
+}
+
+// TestParse port of `test_parse`, run against the `first_match_urls` through
+// `fourth_match_urls` fixtures.
+func (suite *ConformancePublicTestSuite) TestParse() {
+	type test struct {
+		input       string
+		python      map[string]any
+		divergences []divergence
+	}
+
+	tests := []test{
+		// first_match_urls
+		{
+			input: "http://example.com/owner/repo.git",
+			python: map[string]any{
+				"pathname":  "/owner/repo.git",
+				"protocols": []string{"http"},
+				"protocol":  "http",
+				"href":      "http://example.com/owner/repo.git",
+				"resource":  "example.com",
+				"user":      "",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+		},
+		{
+			input: "http://example.com/owner/repo",
+			python: map[string]any{
+				"pathname":  "/owner/repo",
+				"protocols": []string{"http"},
+				"protocol":  "http",
+				"href":      "http://example.com/owner/repo",
+				"resource":  "example.com",
+				"user":      "",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+		},
+		{
+			input: "http://example.com/owner/repo/",
+			python: map[string]any{
+				"pathname":  "/owner/repo/",
+				"protocols": []string{"http"},
+				"protocol":  "http",
+				"href":      "http://example.com/owner/repo/",
+				"resource":  "example.com",
+				"user":      "",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+		},
+		{
+			input: "http://user@example.com/user/repo",
+			python: map[string]any{
+				"pathname":  "/user/repo",
+				"protocols": []string{"http"},
+				"protocol":  "http",
+				"href":      "http://user@example.com/user/repo",
+				"resource":  "example.com",
+				"user":      "user",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "user",
+			},
+		},
+		{
+			input: "http://example.com:29418/owner/repo.git",
+			python: map[string]any{
+				"pathname":  "/owner/repo.git",
+				"protocols": []string{"http"},
+				"protocol":  "http",
+				"href":      "http://example.com:29418/owner/repo.git",
+				"resource":  "example.com",
+				"user":      "",
+				"port":      "29418",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+		},
+		{
+			input: "http://user@example.com:29418/user/repo",
+			python: map[string]any{
+				"pathname":  "/user/repo",
+				"protocols": []string{"http"},
+				"protocol":  "http",
+				"href":      "http://user@example.com:29418/user/repo",
+				"resource":  "example.com",
+				"user":      "user",
+				"port":      "29418",
+				"name":      "repo",
+				"owner":     "user",
+			},
+		},
+		{
+			input: "http://user@example.com:29418/user/repo/",
+			python: map[string]any{
+				"pathname":  "/user/repo/",
+				"protocols": []string{"http"},
+				"protocol":  "http",
+				"href":      "http://user@example.com:29418/user/repo/",
+				"resource":  "example.com",
+				"user":      "user",
+				"port":      "29418",
+				"name":      "repo",
+				"owner":     "user",
+			},
+		},
+		{
+			input: "http://example.com/repo",
+			python: map[string]any{
+				"pathname":  "/repo",
+				"protocols": []string{"http"},
+				"protocol":  "http",
+				"href":      "http://example.com/repo",
+				"resource":  "example.com",
+				"user":      "",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "",
+			},
+		},
+		// the Python fixture only lists these fields
+		{
+			input: "https://example.com/owner/repo.git",
+			python: map[string]any{
+				"pathname":  "/owner/repo.git",
+				"protocols": []string{"https"},
+				"protocol":  "https",
+				"href":      "https://example.com/owner/repo.git",
+				"resource":  "example.com",
+				"user":      "",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+		},
+		{
+			input: "https://example.com/owner/repo",
+			python: map[string]any{
+				"pathname":  "/owner/repo",
+				"protocols": []string{"https"},
+				"protocol":  "https",
+				"href":      "https://example.com/owner/repo",
+				"resource":  "example.com",
+				"user":      "",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+		},
+		{
+			input: "https://user@example.com/user/repo",
+			python: map[string]any{
+				"pathname":  "/user/repo",
+				"protocols": []string{"https"},
+				"protocol":  "https",
+				"href":      "https://user@example.com/user/repo",
+				"resource":  "example.com",
+				"user":      "user",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "user",
+			},
+		},
+		{
+			input: "https://example.com:29418/owner/repo.git",
+			python: map[string]any{
+				"pathname":  "/owner/repo.git",
+				"protocols": []string{"https"},
+				"protocol":  "https",
+				"href":      "https://example.com:29418/owner/repo.git",
+				"resource":  "example.com",
+				"user":      "",
+				"port":      "29418",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+		},
+		{
+			input: "https://user@example.com:29418/user/repo",
+			python: map[string]any{
+				"pathname":  "/user/repo",
+				"protocols": []string{"https"},
+				"protocol":  "https",
+				"href":      "https://user@example.com:29418/user/repo",
+				"resource":  "example.com",
+				"user":      "user",
+				"port":      "29418",
+				"name":      "repo",
+				"owner":     "user",
+			},
+		},
+		{
+			input: "https://example.com/repo",
+			python: map[string]any{
+				"pathname":  "/repo",
+				"protocols": []string{"https"},
+				"protocol":  "https",
+				"href":      "https://example.com/repo",
+				"resource":  "example.com",
+				"user":      "",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "",
+			},
+		},
+		{
+			input: "rsync://example.com/owner/repo.git",
+			python: map[string]any{
+				"pathname":  "/owner/repo.git",
+				"protocols": []string{"rsync"},
+				"protocol":  "rsync",
+				"href":      "rsync://example.com/owner/repo.git",
+				"resource":  "example.com",
+				"user":      "",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+		},
+		{
+			input: "git://example.com/owner/repo.git",
+			python: map[string]any{
+				"pathname":  "/owner/repo.git",
+				"protocols": []string{"git"},
+				"protocol":  "git",
+				"href":      "git://example.com/owner/repo.git",
+				"resource":  "example.com",
+				"user":      "",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+		},
+		{
+			input: "git://example.com/owner/repo",
+			python: map[string]any{
+				"pathname":  "/owner/repo",
+				"protocols": []string{"git"},
+				"protocol":  "git",
+				"href":      "git://example.com/owner/repo",
+				"resource":  "example.com",
+				"user":      "",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+		},
+		{
+			input: "git://example.com/owner/repo/",
+			python: map[string]any{
+				"pathname":  "/owner/repo/",
+				"protocols": []string{"git"},
+				"protocol":  "git",
+				"href":      "git://example.com/owner/repo/",
+				"resource":  "example.com",
+				"user":      "",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+		},
+		{
+			input: "ssh://user@example.com/owner/repo.git",
+			python: map[string]any{
+				"pathname":  "/owner/repo.git",
+				"protocols": []string{"ssh"},
+				"protocol":  "ssh",
+				"href":      "ssh://user@example.com/owner/repo.git",
+				"resource":  "example.com",
+				"user":      "user",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+		},
+		{
+			input: "ssh://user@example.com:29418/owner/repo.git",
+			python: map[string]any{
+				"pathname":  "/owner/repo.git",
+				"protocols": []string{"ssh"},
+				"protocol":  "ssh",
+				"href":      "ssh://user@example.com:29418/owner/repo.git",
+				"resource":  "example.com",
+				"user":      "user",
+				"port":      "29418",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+		},
+		{
+			input: "ssh://example.com/owner/repo.git",
+			python: map[string]any{
+				"pathname":  "/owner/repo.git",
+				"protocols": []string{"ssh"},
+				"protocol":  "ssh",
+				"href":      "ssh://example.com/owner/repo.git",
+				"resource":  "example.com",
+				"user":      "",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+		},
+		{
+			input: "ssh://example.com:29418/owner/repo.git",
+			python: map[string]any{
+				"pathname":  "/owner/repo.git",
+				"protocols": []string{"ssh"},
+				"protocol":  "ssh",
+				"href":      "ssh://example.com:29418/owner/repo.git",
+				"resource":  "example.com",
+				"user":      "",
+				"port":      "29418",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+		},
+		// second_match_urls
+		{
+			input: "git+ssh://example.com/owner/repo.git",
+			python: map[string]any{
+				"pathname":  "/owner/repo.git",
+				"protocols": []string{"git", "ssh"},
+				"protocol":  "ssh",
+				"href":      "git+ssh://example.com/owner/repo.git",
+				"resource":  "example.com",
+				"user":      "",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+			divergences: []divergence{
+				{field: "protocol", want: "git+ssh", reason: reasonMultiProtocol},
+			},
+		},
+		{
+			input: "git+ssh://example.com:9999/owner/repo.git",
+			python: map[string]any{
+				"pathname":  "/owner/repo.git",
+				"protocols": []string{"git", "ssh"},
+				"protocol":  "ssh",
+				"href":      "git+ssh://example.com:9999/owner/repo.git",
+				"resource":  "example.com",
+				"user":      "",
+				"port":      "9999",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+			divergences: []divergence{
+				{field: "protocol", want: "git+ssh", reason: reasonMultiProtocol},
+			},
+		},
+		{
+			input: "git+https://example.com/owner/repo.git",
+			python: map[string]any{
+				"pathname":  "/owner/repo.git",
+				"protocols": []string{"git", "https"},
+				"protocol":  "https",
+				"href":      "git+https://example.com/owner/repo.git",
+				"resource":  "example.com",
+				"user":      "",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+			divergences: []divergence{
+				{field: "protocol", want: "git+https", reason: reasonMultiProtocol},
+			},
+		},
+		{
+			input: "git+https://example.com:9999/owner/repo.git",
+			python: map[string]any{
+				"pathname":  "/owner/repo.git",
+				"protocols": []string{"git", "https"},
+				"protocol":  "https",
+				"href":      "git+https://example.com:9999/owner/repo.git",
+				"resource":  "example.com",
+				"user":      "",
+				"port":      "9999",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+			divergences: []divergence{
+				{field: "protocol", want: "git+https", reason: reasonMultiProtocol},
+			},
+		},
+		// third_match_urls
+		{
+			input: "user@example.com:/owner/repo.git",
+			python: map[string]any{
+				"pathname":  "/owner/repo.git",
+				"protocols": []string{},
+				"protocol":  "ssh",
+				"href":      "user@example.com:/owner/repo.git",
+				"resource":  "example.com",
+				"user":      "user",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+			divergences: []divergence{
+				{field: "protocols", want: []string{"ssh"}, reason: reasonSCPProtocols},
+			},
+		},
+		{
+			input: "user@example.com:owner/repo.git",
+			python: map[string]any{
+				"pathname":  "owner/repo.git",
+				"protocols": []string{},
+				"protocol":  "ssh",
+				"href":      "user@example.com:owner/repo.git",
+				"resource":  "example.com",
+				"user":      "user",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+			divergences: []divergence{
+				{field: "protocols", want: []string{"ssh"}, reason: reasonSCPProtocols},
+			},
+		},
+		{
+			input: "user@foo-example.com:owner/repo.git",
+			python: map[string]any{
+				"pathname":  "owner/repo.git",
+				"protocols": []string{},
+				"protocol":  "ssh",
+				"href":      "user@foo-example.com:owner/repo.git",
+				"resource":  "foo-example.com",
+				"user":      "user",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+			divergences: []divergence{
+				{field: "protocols", want: []string{"ssh"}, reason: reasonSCPProtocols},
+			},
+		},
+		{
+			input: "user@foo-example.com:9999/owner/repo.git",
+			python: map[string]any{
+				"pathname":  "/owner/repo.git",
+				"protocols": []string{},
+				"protocol":  "ssh",
+				"href":      "user@foo-example.com:9999/owner/repo.git",
+				"resource":  "foo-example.com",
+				"user":      "user",
+				"port":      "9999",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+			divergences: []divergence{
+				{field: "protocols", want: []string{"ssh"}, reason: reasonSCPProtocols},
+				{field: "pathname", want: "9999/owner/repo.git", reason: reasonSCPPort},
+				{field: "port", want: "", reason: reasonSCPPort},
+			},
+		},
+		// fourth_match_urls
+		{
+			input: "user@example.com:repo.git",
+			python: map[string]any{
+				"pathname":  "repo.git",
+				"protocols": []string{},
+				"protocol":  "ssh",
+				"href":      "user@example.com:repo.git",
+				"resource":  "example.com",
+				"user":      "user",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "",
+			},
+			divergences: []divergence{
+				{field: "protocols", want: []string{"ssh"}, reason: reasonSCPProtocols},
+			},
+		},
+		{
+			input: "example.com:/owner/repo.git",
+			python: map[string]any{
+				"pathname":  "/owner/repo.git",
+				"protocols": []string{},
+				"protocol":  "ssh",
+				"href":      "example.com:/owner/repo.git",
+				"resource":  "example.com",
+				"user":      "",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+			divergences: []divergence{
+				{field: "protocols", want: []string{"ssh"}, reason: reasonSCPProtocols},
+			},
+		},
+		{
+			input: "example.com:owner/repo.git",
+			python: map[string]any{
+				"pathname":  "owner/repo.git",
+				"protocols": []string{},
+				"protocol":  "ssh",
+				"href":      "example.com:owner/repo.git",
+				"resource":  "example.com",
+				"user":      "",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "owner",
+			},
+			divergences: []divergence{
+				{field: "protocols", want: []string{"ssh"}, reason: reasonSCPProtocols},
+			},
+		},
+		{
+			input: "example.com:repo.git",
+			python: map[string]any{
+				"pathname":  "repo.git",
+				"protocols": []string{},
+				"protocol":  "ssh",
+				"href":      "example.com:repo.git",
+				"resource":  "example.com",
+				"user":      "",
+				"port":      "",
+				"name":      "repo",
+				"owner":     "",
+			},
+			divergences: []divergence{
+				{field: "protocols", want: []string{"ssh"}, reason: reasonSCPProtocols},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := suite.p.Parse(tc.input)
+		require.NoError(suite.T(), err, tc.input)
+
+		want := make(map[string]any, len(tc.python))
+		for field, value := range tc.python {
+			want[field] = value
+		}
+
+		for _, d := range tc.divergences {
+			// a documented divergence must still diverge
+			require.Contains(suite.T(), want, d.field, tc.input)
+			assert.NotEqual(suite.T(), want[d.field], d.want, "%s: %s", tc.input, d.reason)
+			want[d.field] = d.want
+		}
+
+		gotFields := fields(got)
+		for field, value := range want {
+			assert.Equal(suite.T(), value, gotFields[field], "%s: %s", tc.input, field)
+		}
+	}
+}
+
+// TestParseInvalidStrings port of `test_parse_raises_on_invalid_string`.
+func (suite *ConformancePublicTestSuite) TestParseInvalidStrings() {
+	tests := []string{
+		"",
+		"not a valid URL",
+	}
+
+	for _, tc := range tests {
+		_, err := suite.p.Parse(tc)
+
+		assert.Error(suite.T(), err, tc)
+	}
+}
+
+// TestGetProtocols port of the `test_get_protocol_*` tests.
+func (suite *ConformancePublicTestSuite) TestGetProtocols() {
+	type test struct {
+		input   string
+		want    []string
+		wantErr bool
+		reason  string
+	}
+
+	tests := []test{
+		{
+			input: "git+ssh://git@example.com/Owner/Repository.git",
+			want:  []string{"git", "ssh"},
+		},
+		{
+			input: "ssh://git@example.com/Owner/Repository.git",
+			want:  []string{"ssh"},
+		},
+		// Python reports no protocols
+		{
+			input:   "//example.com/foo",
+			wantErr: true,
+			reason:  reasonSchemeless,
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := suite.p.Parse(tc.input)
+
+		if tc.wantErr {
+			assert.Error(suite.T(), err, tc.reason)
+		} else {
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tc.want, got.GetProtocols())
+		}
+	}
+}
+
+// In order for `go test` to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run.
+func TestConformancePublicTestSuite(t *testing.T) {
+	suite.Run(t, new(ConformancePublicTestSuite))
+}
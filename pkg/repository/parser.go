@@ -0,0 +1,145 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package repository
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/retr0h/git-url-parse/internal/repositories"
+	"github.com/retr0h/git-url-parse/pkg"
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+var defaultParser = NewParser(slog.Default())
+
+// NewParser factory to create a new Parser instance.
+func NewParser(
+	logger *slog.Logger,
+) *Parser {
+	return &Parser{
+		logger:   logger,
+		registry: NewRegistry(logger),
+	}
+}
+
+// Registry return the Registry consulted by the parser.
+func (p *Parser) Registry() *Registry { return p.registry }
+
+// WithRefResolver return a copy of the parser, sharing its Registry, which
+// splits ambiguous refs from their paths with the refs listed by the
+// resolver.
+func (p *Parser) WithRefResolver(resolver pkg.RefResolver) *Parser {
+	return &Parser{
+		logger:     p.logger,
+		registry:   p.registry,
+		resolver:   resolver,
+		strictRefs: p.strictRefs,
+	}
+}
+
+// WithStrictRefs return a copy of the parser, sharing its Registry, which
+// fails with an `*api.AmbiguousRefError` to parse URLs whose ref is still
+// ambiguous once resolved.
+func (p *Parser) WithStrictRefs(strict bool) *Parser {
+	return &Parser{
+		logger:     p.logger,
+		registry:   p.registry,
+		resolver:   p.resolver,
+		strictRefs: strict,
+	}
+}
+
+// Parse the URL via the parser registered for its host.  Safe for concurrent
+// use.
+func (p *Parser) Parse(url string) (pkg.RepositoryManager, error) {
+	url = repositories.RedactURL(url)
+	u, err := repositories.Tokenize(url)
+	if err != nil {
+		return nil, err
+	}
+
+	match, err := p.registry.Resolve(getHost(u))
+	if err != nil {
+		return nil, withURL(err, url)
+	}
+
+	repo, err := parseMatch(match.Provider.Parser, match, url, u)
+	if err != nil {
+		return nil, err
+	}
+
+	if repo.RefAmbiguous && p.resolver != nil {
+		refs, err := p.resolver.ResolveRefs(repo)
+		if err != nil {
+			return nil, fmt.Errorf("could not resolve refs of url: %s: %w", repo.HREF, err)
+		}
+
+		resolveRef(repo, refs)
+	}
+
+	if repo.RefAmbiguous && p.strictRefs {
+		return nil, &api.AmbiguousRefError{
+			URL:      repo.HREF,
+			Host:     repo.Host,
+			Provider: repo.Provider,
+			Ref:      repo.Ref,
+			Path:     repo.Path,
+		}
+	}
+
+	return repo, nil
+}
+
+// Parse the URL with the package's default Parser.  Safe for concurrent use.
+func Parse(url string) (pkg.RepositoryManager, error) {
+	return defaultParser.Parse(url)
+}
+
+// Register add the parser to the default Parser's Registry.
+func Register(
+	name string,
+	priority int,
+	parser pkg.ParserManager,
+) error {
+	return defaultParser.Registry().Register(name, priority, parser)
+}
+
+// Unregister remove the named provider from the default Parser's Registry.
+func Unregister(name string) error {
+	return defaultParser.Registry().Unregister(name)
+}
+
+// EnableHeuristics toggle the default Parser's Registry heuristics.
+func EnableHeuristics(enabled bool) {
+	defaultParser.Registry().EnableHeuristics(enabled)
+}
+
+// RegisterHost declare a self-hosted instance with the default Parser's
+// Registry.
+func RegisterHost(host Host) error {
+	return defaultParser.Registry().RegisterHost(host)
+}
+
+// UnregisterHost remove the declared host from the default Parser's Registry.
+func UnregisterHost(name string) error {
+	return defaultParser.Registry().UnregisterHost(name)
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package repository
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/retr0h/git-url-parse/internal/repositories"
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+// RefList a RefResolver listing the same refs for every repo.
+type RefList []api.Ref
+
+// ResolveRefs return the listed refs.
+func (rl RefList) ResolveRefs(_ *api.Repository) ([]api.Ref, error) {
+	return rl, nil
+}
+
+// ParseLsRemote read the output of `git ls-remote`, as in
+// `<hash>\trefs/heads/feature/login`, into the listed branches and tags.
+// Peeled tags, `HEAD` and other refs are skipped.
+func ParseLsRemote(r io.Reader) (RefList, error) {
+	var refs RefList
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.HasSuffix(fields[1], "^{}") {
+			continue
+		}
+
+		if name, ok := strings.CutPrefix(fields[1], "refs/heads/"); ok {
+			refs = append(refs, api.Ref{Kind: api.RefKindBranch, Name: name})
+		} else if name, ok := strings.CutPrefix(fields[1], "refs/tags/"); ok {
+			refs = append(refs, api.Ref{Kind: api.RefKindTag, Name: name})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return refs, nil
+}
+
+// resolveRef split the repo's ref from its path at the longest listed ref
+// which prefixes them, as `feature/login` in `feature/login/src`.  Refs of
+// another kind than the URL names are skipped.  The repo stays ambiguous when
+// no listed ref matches.
+func resolveRef(repo *api.Repository, refs []api.Ref) {
+	revision := repo.Ref.Name + "/" + strings.TrimPrefix(repo.Path, "/")
+
+	var found *api.Ref
+	for i, ref := range refs {
+		if repo.Ref.Kind != api.RefKindUnknown && ref.Kind != repo.Ref.Kind {
+			continue
+		}
+
+		if revision != ref.Name && !strings.HasPrefix(revision, ref.Name+"/") {
+			continue
+		}
+
+		if found == nil || len(ref.Name) > len(found.Name) {
+			found = &refs[i]
+		}
+	}
+
+	if found == nil {
+		return
+	}
+
+	repo.Branch = repositories.BranchName(*found)
+	repo.Path = strings.TrimPrefix(strings.TrimPrefix(revision, found.Name), "/")
+	repo.Ref = *found
+	repo.RefAmbiguous = false
+}
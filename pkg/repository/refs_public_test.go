@@ -0,0 +1,245 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package repository_test
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/retr0h/git-url-parse/pkg/api"
+	"github.com/retr0h/git-url-parse/pkg/repository"
+)
+
+const lsRemote = `4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a	HEAD
+4502b9b51ee3ac1ea649bacfa0f48ebdeab05f4a	refs/heads/main
+1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d	refs/heads/feature
+5f6e7d8c9b0a1f2e3d4c5b6a7f8e9d0c1b2a3f4e	refs/heads/feature/login
+9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b	refs/pull/1/head
+0a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b	refs/tags/release/v1.0
+0a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b	refs/tags/release/v1.0^{}
+`
+
+type errResolver struct{}
+
+func (er *errResolver) ResolveRefs(_ *api.Repository) ([]api.Ref, error) {
+	return nil, fmt.Errorf("remote unreachable")
+}
+
+type RefsPublicTestSuite struct {
+	suite.Suite
+
+	p *repository.Parser
+
+	logger *slog.Logger
+}
+
+func (suite *RefsPublicTestSuite) SetupTest() {
+	suite.logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	refs, err := repository.ParseLsRemote(strings.NewReader(lsRemote))
+	require.NoError(suite.T(), err)
+
+	suite.p = repository.NewParser(suite.logger).WithRefResolver(refs)
+}
+
+func (suite *RefsPublicTestSuite) TestParseLsRemote() {
+	got, err := repository.ParseLsRemote(strings.NewReader(lsRemote))
+	require.NoError(suite.T(), err)
+
+	want := repository.RefList{
+		{Kind: api.RefKindBranch, Name: "main"},
+		{Kind: api.RefKindBranch, Name: "feature"},
+		{Kind: api.RefKindBranch, Name: "feature/login"},
+		{Kind: api.RefKindTag, Name: "release/v1.0"},
+	}
+	assert.Equal(suite.T(), want, got)
+}
+
+func (suite *RefsPublicTestSuite) TestParseWithRefResolver() {
+	type test struct {
+		input     string
+		branch    string
+		path      string
+		ref       api.Ref
+		ambiguous bool
+	}
+
+	login := api.Ref{Kind: api.RefKindBranch, Name: "feature/login"}
+	tests := []test{
+		{
+			input:     "https://github.com/o/r/tree/feature/login/src",
+			branch:    "feature/login",
+			path:      "src",
+			ref:       login,
+			ambiguous: false,
+		},
+		{
+			input:     "https://github.com/o/r/blob/feature/x.go",
+			branch:    "feature",
+			path:      "x.go",
+			ref:       api.Ref{Kind: api.RefKindBranch, Name: "feature"},
+			ambiguous: false,
+		},
+		{
+			input:     "https://github.com/o/r/tree/feature/login",
+			branch:    "feature/login",
+			path:      "",
+			ref:       login,
+			ambiguous: false,
+		},
+		{
+			input:     "https://gitlab.com/group/sub/r/-/blob/feature/login/src/x.go",
+			branch:    "feature/login",
+			path:      "src/x.go",
+			ref:       login,
+			ambiguous: false,
+		},
+		{
+			input:     "https://bitbucket.org/o/r/src/feature/login/src/x.go",
+			branch:    "feature/login",
+			path:      "src/x.go",
+			ref:       login,
+			ambiguous: false,
+		},
+		{
+			input:     "https://codeberg.org/o/r/src/tag/release/v1.0/x.go",
+			branch:    "",
+			path:      "x.go",
+			ref:       api.Ref{Kind: api.RefKindTag, Name: "release/v1.0"},
+			ambiguous: false,
+		},
+		{
+			input:     "https://github.com/o/r/blob/release/v1.0/x.go",
+			branch:    "",
+			path:      "x.go",
+			ref:       api.Ref{Kind: api.RefKindTag, Name: "release/v1.0"},
+			ambiguous: false,
+		},
+		{
+			input:     "https://git.sr.ht/~o/r/blob/feature/login/src/x.go",
+			branch:    "feature/login",
+			path:      "src/x.go",
+			ref:       login,
+			ambiguous: false,
+		},
+		{
+			input:     "https://go.googlesource.com/r/+/refs/heads/feature/login/src",
+			branch:    "feature/login",
+			path:      "src",
+			ref:       login,
+			ambiguous: false,
+		},
+		{
+			input:     "https://github.com/o/r/tree/unknown/login/src",
+			branch:    "unknown",
+			path:      "login/src",
+			ref:       api.Ref{Name: "unknown"},
+			ambiguous: true,
+		},
+		{
+			input:     "https://github.com/o/r/tree/main",
+			branch:    "main",
+			path:      "",
+			ref:       api.Ref{Name: "main"},
+			ambiguous: false,
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := suite.p.Parse(tc.input)
+		require.NoError(suite.T(), err, tc.input)
+
+		assert.Equal(suite.T(), tc.branch, got.GetBranchName(), tc.input)
+		assert.Equal(suite.T(), tc.path, got.GetPath(), tc.input)
+		assert.Equal(suite.T(), tc.ref, got.GetRef(), tc.input)
+		assert.Equal(suite.T(), tc.ambiguous, got.GetRefAmbiguous(), tc.input)
+	}
+}
+
+func (suite *RefsPublicTestSuite) TestParseWithoutRefResolver() {
+	p := repository.NewParser(suite.logger)
+
+	got, err := p.Parse("https://github.com/o/r/tree/feature/login/src")
+	require.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), "feature", got.GetBranchName())
+	assert.Equal(suite.T(), "login/src", got.GetPath())
+	assert.True(suite.T(), got.GetRefAmbiguous())
+}
+
+func (suite *RefsPublicTestSuite) TestParseWithStrictRefs() {
+	p := suite.p.WithStrictRefs(true)
+
+	got, err := p.Parse("https://github.com/o/r/tree/feature/login/src")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "feature/login", got.GetBranchName())
+
+	_, err = p.Parse("https://github.com/o/r/tree/unknown/login/src")
+	require.ErrorIs(suite.T(), err, api.ErrAmbiguousRef)
+
+	var are *api.AmbiguousRefError
+	require.True(suite.T(), errors.As(err, &are))
+	assert.Equal(suite.T(), "github", are.Provider)
+	assert.Equal(suite.T(), "github.com", are.Host)
+	assert.Equal(suite.T(), api.Ref{Name: "unknown"}, are.Ref)
+	assert.Equal(suite.T(), "login/src", are.Path)
+
+	_, err = p.Parse("https://go.googlesource.com/r/+/refs/heads/unknown/login/src")
+	assert.ErrorIs(suite.T(), err, api.ErrAmbiguousRef)
+
+	// without a resolver every ambiguous URL fails
+	p = repository.NewParser(suite.logger).WithStrictRefs(true)
+	_, err = p.Parse("https://github.com/o/r/tree/feature/login/src")
+	assert.ErrorIs(suite.T(), err, api.ErrAmbiguousRef)
+
+	// options are kept by the copies of the parser
+	refs, err := repository.ParseLsRemote(strings.NewReader(lsRemote))
+	require.NoError(suite.T(), err)
+
+	p = p.WithRefResolver(refs)
+	_, err = p.Parse("https://github.com/o/r/tree/unknown/login/src")
+	assert.ErrorIs(suite.T(), err, api.ErrAmbiguousRef)
+}
+
+func (suite *RefsPublicTestSuite) TestParseWithFailingRefResolver() {
+	p := repository.NewParser(suite.logger).WithRefResolver(&errResolver{})
+
+	_, err := p.Parse("https://github.com/o/r/tree/feature/login/src")
+	assert.Error(suite.T(), err)
+
+	// unambiguous URLs never consult the resolver
+	_, err = p.Parse("https://github.com/o/r")
+	assert.NoError(suite.T(), err)
+}
+
+// In order for `go test` to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run.
+func TestRefsPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(RefsPublicTestSuite))
+}
@@ -0,0 +1,353 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package repository
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/retr0h/git-url-parse/internal/repositories/azure"
+	"github.com/retr0h/git-url-parse/internal/repositories/bitbucket"
+	"github.com/retr0h/git-url-parse/internal/repositories/bitbucketserver"
+	"github.com/retr0h/git-url-parse/internal/repositories/codecommit"
+	"github.com/retr0h/git-url-parse/internal/repositories/generic"
+	"github.com/retr0h/git-url-parse/internal/repositories/gerrit"
+	"github.com/retr0h/git-url-parse/internal/repositories/gitea"
+	"github.com/retr0h/git-url-parse/internal/repositories/github"
+	"github.com/retr0h/git-url-parse/internal/repositories/gitiles"
+	"github.com/retr0h/git-url-parse/internal/repositories/gitlab"
+	"github.com/retr0h/git-url-parse/internal/repositories/sourcehut"
+	"github.com/retr0h/git-url-parse/pkg"
+	"github.com/retr0h/git-url-parse/pkg/api"
+)
+
+// Priorities of the built-in providers.  Providers are consulted from the
+// lowest to the highest priority, ties are broken by name.  Register with a
+// lower priority to take precedence over a built-in provider.
+const (
+	PriorityBitbucket       int = 100
+	PriorityGitHub          int = 200
+	PriorityGitLab          int = 300
+	PriorityAzure           int = 400
+	PriorityBitbucketServer int = 500
+	PriorityGitea           int = 600
+	PriorityGerrit          int = 700
+	PriorityCodeCommit      int = 800
+	PriorityGitiles         int = 900
+	PrioritySourceHut       int = 1000
+	PriorityGeneric         int = 1100
+)
+
+// maxIndexedHosts the number of hosts whose Match is indexed, so URLs of
+// arbitrary hosts do not grow the index without bound.  Hosts past it are
+// resolved on every lookup.
+const maxIndexedHosts int64 = 10000
+
+// NewRegistry factory to create a new Registry instance, containing the
+// built-in providers.
+func NewRegistry(
+	logger *slog.Logger,
+) *Registry {
+	r := &Registry{
+		hosts: make(map[string]Host),
+		index: new(sync.Map),
+	}
+
+	// add additional providers
+	_ = r.Register("bitbucket", PriorityBitbucket, bitbucket.New(logger))
+	_ = r.Register("github", PriorityGitHub, github.New(logger))
+	_ = r.Register("gitlab", PriorityGitLab, gitlab.New(logger))
+	_ = r.Register("azure", PriorityAzure, azure.New(logger))
+	_ = r.Register("bitbucket-server", PriorityBitbucketServer, bitbucketserver.New(logger))
+	_ = r.Register("gitea", PriorityGitea, gitea.New(logger))
+	_ = r.Register("gerrit", PriorityGerrit, gerrit.New(logger))
+	_ = r.Register("codecommit", PriorityCodeCommit, codecommit.New(logger))
+	_ = r.Register("gitiles", PriorityGitiles, gitiles.New(logger))
+	_ = r.Register("sourcehut", PrioritySourceHut, sourcehut.New(logger))
+	_ = r.Register("generic", PriorityGeneric, generic.New(logger))
+
+	return r
+}
+
+// Register add the parser under the provided name.  Names must be unique.
+func (r *Registry) Register(
+	name string,
+	priority int,
+	parser pkg.ParserManager,
+) error {
+	if name == "" {
+		return fmt.Errorf("provider name must not be empty")
+	}
+
+	if parser == nil {
+		return fmt.Errorf("provider: %s has a nil parser", name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.indexOf(name) != -1 {
+		return fmt.Errorf("provider: %s already registered", name)
+	}
+
+	r.providers = append(r.providers, Provider{
+		Name:     name,
+		Priority: priority,
+		Parser:   parser,
+	})
+	sort.SliceStable(r.providers, func(i, j int) bool {
+		if r.providers[i].Priority != r.providers[j].Priority {
+			return r.providers[i].Priority < r.providers[j].Priority
+		}
+
+		return r.providers[i].Name < r.providers[j].Name
+	})
+	r.clearIndex()
+
+	return nil
+}
+
+// Unregister remove the provider registered under the provided name, and the
+// hosts declared for it.
+func (r *Registry) Unregister(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.indexOf(name)
+	if i == -1 {
+		return fmt.Errorf("provider: %s not registered", name)
+	}
+
+	r.providers = append(r.providers[:i], r.providers[i+1:]...)
+	for hostName, host := range r.hosts {
+		if host.Provider == name {
+			delete(r.hosts, hostName)
+		}
+	}
+	r.clearIndex()
+
+	return nil
+}
+
+// RegisterHost declare a self-hosted instance of a registered provider.
+// Declared hosts, and their subdomains, are matched before any provider's
+// `ShouldParse`.  The host's prefix is normalized to a single leading `/`.
+func (r *Registry) RegisterHost(host Host) error {
+	if host.Name == "" {
+		return fmt.Errorf("host name must not be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.indexOf(host.Provider) == -1 {
+		return fmt.Errorf("provider: %s not registered", host.Provider)
+	}
+
+	host.Name = strings.ToLower(host.Name)
+	if prefix := strings.Trim(host.Prefix, "/"); prefix != "" {
+		host.Prefix = "/" + prefix
+	} else {
+		host.Prefix = ""
+	}
+	r.hosts[host.Name] = host
+	r.clearIndex()
+
+	return nil
+}
+
+// UnregisterHost remove the declared host.
+func (r *Registry) UnregisterHost(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name = strings.ToLower(name)
+	if _, ok := r.hosts[name]; !ok {
+		return fmt.Errorf("host: %s not registered", name)
+	}
+
+	delete(r.hosts, name)
+	r.clearIndex()
+
+	return nil
+}
+
+// Providers return a copy of the registered providers in lookup order.
+func (r *Registry) Providers() []Provider {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	providers := make([]Provider, len(r.providers))
+	copy(providers, r.providers)
+
+	return providers
+}
+
+// EnableHeuristics consult, once every other rule fails, the providers
+// implementing `pkg.HeuristicMatcher`.  Disabled by default.
+func (r *Registry) EnableHeuristics(enabled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.heuristics = enabled
+	r.clearIndex()
+}
+
+// Lookup return the provider which parses the provided host.
+func (r *Registry) Lookup(host string) (Provider, error) {
+	match, err := r.Resolve(host)
+	if err != nil {
+		return Provider{}, err
+	}
+
+	return match.Provider, nil
+}
+
+// Resolve return the provider which parses the provided host, and the rule
+// which selected it.  Rules are consulted in order:
+//
+//  1. MatchRuleDeclared the provider declared for the host with RegisterHost.
+//  2. MatchRuleProvider the first provider, in lookup order, whose
+//     ShouldParse returns true.
+//  3. MatchRuleHeuristic the first provider, in lookup order, whose
+//     MatchHeuristic returns true; only when heuristics are enabled.
+//  4. MatchRuleFallback the first provider, in lookup order, whose
+//     MatchFallback returns true.
+//
+// Matches are indexed by host, so each host is resolved once until the
+// registry changes; providers' matchers must answer the same for a host.
+func (r *Registry) Resolve(host string) (Match, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if match, ok := r.index.Load(host); ok {
+		return match.(Match), nil
+	}
+
+	match, err := r.resolve(host)
+	if err != nil {
+		return Match{}, err
+	}
+
+	if r.indexSize.Load() < maxIndexedHosts {
+		if _, loaded := r.index.LoadOrStore(host, match); !loaded {
+			r.indexSize.Add(1)
+		}
+	}
+
+	return match, nil
+}
+
+// resolve return the provider which parses the provided host, following the
+// rules of Resolve.  Callers must hold the lock.
+func (r *Registry) resolve(host string) (Match, error) {
+	// RegisterHost only declares hosts of registered providers, and
+	// Unregister removes a provider's hosts, so a declared host's provider
+	// is always registered.
+	if h, ok := r.declaredHost(host); ok {
+		return Match{
+			Provider: r.providers[r.indexOf(h.Provider)],
+			Rule:     api.MatchRuleDeclared,
+			Host:     h,
+		}, nil
+	}
+
+	for _, provider := range r.providers {
+		if provider.Parser.ShouldParse(host) {
+			return Match{
+				Provider: provider,
+				Rule:     api.MatchRuleProvider,
+			}, nil
+		}
+	}
+
+	if r.heuristics {
+		for _, provider := range r.providers {
+			hm, ok := provider.Parser.(pkg.HeuristicMatcher)
+			if ok && hm.MatchHeuristic(host) {
+				return Match{
+					Provider: provider,
+					Rule:     api.MatchRuleHeuristic,
+				}, nil
+			}
+		}
+	}
+
+	for _, provider := range r.providers {
+		fm, ok := provider.Parser.(pkg.FallbackMatcher)
+		if ok && fm.MatchFallback(host) {
+			return Match{
+				Provider: provider,
+				Rule:     api.MatchRuleFallback,
+			}, nil
+		}
+	}
+
+	return Match{}, &api.UnsupportedHostError{Host: host, Providers: r.providerNames()}
+}
+
+// providerNames return the names of the registered providers in lookup order.
+// Callers must hold the lock.
+func (r *Registry) providerNames() []string {
+	names := make([]string, len(r.providers))
+	for i, provider := range r.providers {
+		names[i] = provider.Name
+	}
+
+	return names
+}
+
+// clearIndex forget the indexed matches.  Callers must hold the write lock.
+func (r *Registry) clearIndex() {
+	r.index = new(sync.Map)
+	r.indexSize.Store(0)
+}
+
+// declaredHost return the host declared for the provided host, or for its
+// closest parent domain, so a declared `github.example.com` also matches
+// `raw.github.example.com`.  Callers must hold the lock.
+func (r *Registry) declaredHost(host string) (Host, bool) {
+	host = strings.ToLower(host)
+	for host != "" {
+		if h, ok := r.hosts[host]; ok {
+			return h, true
+		}
+
+		_, host, _ = strings.Cut(host, ".")
+	}
+
+	return Host{}, false
+}
+
+// indexOf return the index of the named provider, or -1.  Callers must hold
+// the lock.
+func (r *Registry) indexOf(name string) int {
+	for i, provider := range r.providers {
+		if provider.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
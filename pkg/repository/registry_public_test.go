@@ -0,0 +1,466 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package repository_test
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/retr0h/git-url-parse/pkg/api"
+	"github.com/retr0h/git-url-parse/pkg/repository"
+)
+
+type fakeParser struct {
+	host string
+	name string
+}
+
+func (f *fakeParser) ShouldParse(host string) bool { return host == f.host }
+
+func (f *fakeParser) Parse(url string) (*api.Repository, error) {
+	return &api.Repository{Provider: f.name, HREF: url}, nil
+}
+
+type RegistryPublicTestSuite struct {
+	suite.Suite
+
+	r *repository.Registry
+
+	logger *slog.Logger
+}
+
+func (suite *RegistryPublicTestSuite) SetupTest() {
+	suite.logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	suite.r = repository.NewRegistry(suite.logger)
+}
+
+func (suite *RegistryPublicTestSuite) names() []string {
+	names := []string{}
+	for _, p := range suite.r.Providers() {
+		names = append(names, p.Name)
+	}
+
+	return names
+}
+
+func (suite *RegistryPublicTestSuite) TestNewRegistryHasBuiltins() {
+	want := []string{
+		"bitbucket",
+		"github",
+		"gitlab",
+		"azure",
+		"bitbucket-server",
+		"gitea",
+		"gerrit",
+		"codecommit",
+		"gitiles",
+		"sourcehut",
+		"generic",
+	}
+	assert.Equal(suite.T(), want, suite.names())
+}
+
+func (suite *RegistryPublicTestSuite) TestRegisterOrdersByPriorityThenName() {
+	err := suite.r.Register("zeta", repository.PriorityGitHub, &fakeParser{})
+	require.NoError(suite.T(), err)
+	err = suite.r.Register("alpha", repository.PriorityGitHub, &fakeParser{})
+	require.NoError(suite.T(), err)
+	err = suite.r.Register("first", 0, &fakeParser{})
+	require.NoError(suite.T(), err)
+
+	want := []string{
+		"first",
+		"bitbucket",
+		"alpha",
+		"github",
+		"zeta",
+		"gitlab",
+		"azure",
+		"bitbucket-server",
+		"gitea",
+		"gerrit",
+		"codecommit",
+		"gitiles",
+		"sourcehut",
+		"generic",
+	}
+	assert.Equal(suite.T(), want, suite.names())
+}
+
+func (suite *RegistryPublicTestSuite) TestRegisterErrors() {
+	type test struct {
+		name   string
+		parser *fakeParser
+	}
+
+	tests := []test{
+		{
+			name:   "",
+			parser: &fakeParser{},
+		},
+		{
+			name:   "github",
+			parser: &fakeParser{},
+		},
+		{
+			name:   "custom",
+			parser: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		var err error
+		if tc.parser == nil {
+			err = suite.r.Register(tc.name, 0, nil)
+		} else {
+			err = suite.r.Register(tc.name, 0, tc.parser)
+		}
+
+		assert.Error(suite.T(), err)
+	}
+}
+
+func (suite *RegistryPublicTestSuite) TestUnregister() {
+	err := suite.r.Unregister("github")
+	require.NoError(suite.T(), err)
+
+	want := []string{
+		"bitbucket",
+		"gitlab",
+		"azure",
+		"bitbucket-server",
+		"gitea",
+		"gerrit",
+		"codecommit",
+		"gitiles",
+		"sourcehut",
+		"generic",
+	}
+	assert.Equal(suite.T(), want, suite.names())
+
+	got, err := suite.r.Lookup("github.com")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "generic", got.Name)
+
+	err = suite.r.Unregister("github")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *RegistryPublicTestSuite) TestLookup() {
+	err := suite.r.Register("forge", 0, &fakeParser{host: "forge.example.com"})
+	require.NoError(suite.T(), err)
+
+	type test struct {
+		input   string
+		want    string
+		wantErr bool
+	}
+
+	tests := []test{
+		{
+			input:   "forge.example.com",
+			want:    "forge",
+			wantErr: false,
+		},
+		{
+			input:   "github.com",
+			want:    "github",
+			wantErr: false,
+		},
+		{
+			input:   "example.com",
+			want:    "generic",
+			wantErr: false,
+		},
+		// failure cases
+		{
+			input:   "",
+			want:    "",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := suite.r.Lookup(tc.input)
+
+		if tc.wantErr {
+			assert.Error(suite.T(), err)
+		} else {
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tc.want, got.Name)
+		}
+	}
+}
+
+func (suite *RegistryPublicTestSuite) TestLookupPriorityOverridesBuiltin() {
+	err := suite.r.Register("mirror", 0, &fakeParser{host: "github.com", name: "mirror"})
+	require.NoError(suite.T(), err)
+
+	got, err := suite.r.Lookup("github.com")
+	require.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), "mirror", got.Name)
+}
+
+func (suite *RegistryPublicTestSuite) TestLookupAfterRegisterIsNotStale() {
+	got, err := suite.r.Lookup("github.com")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "github", got.Name)
+
+	err = suite.r.Register("mirror", 0, &fakeParser{host: "github.com", name: "mirror"})
+	require.NoError(suite.T(), err)
+
+	got, err = suite.r.Lookup("github.com")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "mirror", got.Name)
+
+	err = suite.r.Unregister("mirror")
+	require.NoError(suite.T(), err)
+
+	got, err = suite.r.Lookup("github.com")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "github", got.Name)
+}
+
+func (suite *RegistryPublicTestSuite) TestRegisterHost() {
+	err := suite.r.RegisterHost(repository.Host{
+		Name:     "Bitbucket.Example.com",
+		Provider: "bitbucket-server",
+	})
+	require.NoError(suite.T(), err)
+
+	got, err := suite.r.Lookup("bitbucket.example.com")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "bitbucket-server", got.Name)
+
+	// declared hosts take precedence over ShouldParse
+	err = suite.r.RegisterHost(repository.Host{
+		Name:     "github.com",
+		Provider: "gitlab",
+	})
+	require.NoError(suite.T(), err)
+
+	got, err = suite.r.Lookup("github.com")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "gitlab", got.Name)
+
+	err = suite.r.UnregisterHost("github.com")
+	require.NoError(suite.T(), err)
+
+	got, err = suite.r.Lookup("github.com")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "github", got.Name)
+}
+
+func (suite *RegistryPublicTestSuite) TestRegisterHostMatchesSubdomains() {
+	err := suite.r.RegisterHost(repository.Host{
+		Name:     "github.mycorp.net",
+		Provider: "github",
+	})
+	require.NoError(suite.T(), err)
+
+	type test struct {
+		input   string
+		want    string
+		wantErr bool
+	}
+
+	tests := []test{
+		{
+			input:   "github.mycorp.net",
+			want:    "github",
+			wantErr: false,
+		},
+		{
+			input:   "raw.github.mycorp.net",
+			want:    "github",
+			wantErr: false,
+		},
+		{
+			input:   "mycorp.net",
+			want:    "generic",
+			wantErr: false,
+		},
+		{
+			input:   "notgithub.mycorp.net",
+			want:    "generic",
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := suite.r.Lookup(tc.input)
+
+		if tc.wantErr {
+			assert.Error(suite.T(), err)
+		} else {
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tc.want, got.Name)
+		}
+	}
+}
+
+func (suite *RegistryPublicTestSuite) TestResolve() {
+	err := suite.r.RegisterHost(repository.Host{
+		Name:     "code.corp.com",
+		Provider: "gitlab",
+	})
+	require.NoError(suite.T(), err)
+
+	type test struct {
+		input      string
+		heuristics bool
+		want       string
+		wantRule   api.MatchRule
+		wantErr    bool
+	}
+
+	tests := []test{
+		{
+			input:    "code.corp.com",
+			want:     "gitlab",
+			wantRule: api.MatchRuleDeclared,
+			wantErr:  false,
+		},
+		{
+			input:    "gitlab.com",
+			want:     "gitlab",
+			wantRule: api.MatchRuleProvider,
+			wantErr:  false,
+		},
+		{
+			input:      "gitlab.example.com",
+			heuristics: true,
+			want:       "gitlab",
+			wantRule:   api.MatchRuleHeuristic,
+			wantErr:    false,
+		},
+		{
+			input:      "gitea.example.com",
+			heuristics: true,
+			want:       "gitea",
+			wantRule:   api.MatchRuleHeuristic,
+			wantErr:    false,
+		},
+		{
+			input:      "review.example.com",
+			heuristics: true,
+			want:       "gerrit",
+			wantRule:   api.MatchRuleHeuristic,
+			wantErr:    false,
+		},
+		{
+			input:    "gitlab.example.com",
+			want:     "generic",
+			wantRule: api.MatchRuleFallback,
+			wantErr:  false,
+		},
+		{
+			input:      "notgitlab.example.com",
+			heuristics: true,
+			want:       "generic",
+			wantRule:   api.MatchRuleFallback,
+			wantErr:    false,
+		},
+		{
+			input:      "gitlab-mirror.github.io",
+			heuristics: true,
+			want:       "generic",
+			wantRule:   api.MatchRuleFallback,
+			wantErr:    false,
+		},
+		// failure cases
+		{
+			input:      "",
+			heuristics: true,
+			want:       "",
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		suite.r.EnableHeuristics(tc.heuristics)
+		got, err := suite.r.Resolve(tc.input)
+
+		if tc.wantErr {
+			assert.Error(suite.T(), err)
+		} else {
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tc.want, got.Provider.Name)
+			assert.Equal(suite.T(), tc.wantRule, got.Rule)
+		}
+	}
+}
+
+func (suite *RegistryPublicTestSuite) TestRegisterHostErrors() {
+	type test struct {
+		input repository.Host
+	}
+
+	tests := []test{
+		{
+			input: repository.Host{Name: "", Provider: "github"},
+		},
+		{
+			input: repository.Host{Name: "git.example.com", Provider: "unknown"},
+		},
+	}
+
+	for _, tc := range tests {
+		err := suite.r.RegisterHost(tc.input)
+
+		assert.Error(suite.T(), err)
+	}
+
+	err := suite.r.UnregisterHost("git.example.com")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *RegistryPublicTestSuite) TestUnregisterRemovesDeclaredHosts() {
+	err := suite.r.RegisterHost(repository.Host{
+		Name:     "git.example.com",
+		Provider: "gitlab",
+	})
+	require.NoError(suite.T(), err)
+
+	err = suite.r.Unregister("gitlab")
+	require.NoError(suite.T(), err)
+
+	got, err := suite.r.Resolve("git.example.com")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "generic", got.Provider.Name)
+	assert.Equal(suite.T(), api.MatchRuleFallback, got.Rule)
+
+	err = suite.r.UnregisterHost("git.example.com")
+	assert.Error(suite.T(), err)
+}
+
+// In order for `go test` to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run.
+func TestRegistryPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(RegistryPublicTestSuite))
+}
@@ -21,59 +21,69 @@
 package repository
 
 import (
-	"fmt"
+	"errors"
 	"log/slog"
-
-	giturls "github.com/chainguard-dev/git-urls"
+	"strings"
 
 	"github.com/retr0h/git-url-parse/internal"
-	"github.com/retr0h/git-url-parse/internal/repositories/bitbucket"
-	"github.com/retr0h/git-url-parse/internal/repositories/github"
-	"github.com/retr0h/git-url-parse/internal/repositories/gitlab"
+	"github.com/retr0h/git-url-parse/internal/repositories"
 	"github.com/retr0h/git-url-parse/pkg"
+	"github.com/retr0h/git-url-parse/pkg/api"
+	"github.com/retr0h/git-url-parse/pkg/giturl"
 )
 
-// New factory to create a new Repository instance.
+// remoteHelpers git remote helpers with URLs which do not name a host.
+var remoteHelpers = []string{
+	"codecommit",
+}
+
+// New factory to create a new Repository instance.  Repositories resolve
+// providers with the default Parser's Registry, so providers and hosts
+// registered with the package-level functions apply to them too.
 func New(
 	// url string,
 	logger *slog.Logger,
 ) *Repository {
 	return &Repository{
-		logger: logger,
+		logger:   logger,
+		registry: defaultParser.Registry(),
 	}
 }
 
 // RegisterParser register the parser to be used.
 func (r *Repository) RegisterParser(url string) error {
-	host, err := getHost(url)
+	redacted := repositories.RedactURL(url)
+	u, err := repositories.Tokenize(redacted)
 	if err != nil {
 		return err
 	}
 
-	var bb internal.ParserManager = bitbucket.New(r.logger)
-	var gh internal.ParserManager = github.New(r.logger)
-	var gl internal.ParserManager = gitlab.New(r.logger)
-	r.SetURL(url)
-
-	// add additional parsers
-	if bb.ShouldParse(host) {
-		r.SetParser(bb)
-	} else if gh.ShouldParse(host) {
-		r.SetParser(gh)
-	} else if gl.ShouldParse(host) {
-		r.SetParser(gl)
-	} else {
-		return fmt.Errorf("could not find parser for host: %s", host)
+	match, err := r.registry.Resolve(getHost(u))
+	if err != nil {
+		return withURL(err, redacted)
 	}
 
+	r.SetURL(redacted)
+	r.SetParser(match.Provider.Parser)
+	r.match = match
+
 	return nil
 }
 
 // Parse the URL via the delegated parser.
 func (r *Repository) Parse() (pkg.RepositoryManager, error) {
 	url := r.GetURL()
+	u, err := repositories.Tokenize(url)
+	if err != nil {
+		return nil, err
+	}
 
-	return r.parser.Parse(url)
+	repo, err := parseMatch(r.parser, r.match, url, u)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo, nil
 }
 
 // SetParser set the parser to be used.
@@ -82,21 +92,105 @@ func (r *Repository) SetParser(parser internal.ParserManager) { r.parser = parse
 // GetParser getthe parser to be used.
 func (r *Repository) GetParser() internal.ParserManager { return r.parser }
 
-// SetURL set the URL to be parsed.
-func (r *Repository) SetURL(url string) { r.url = url }
+// SetURL set the URL to be parsed, with its password redacted.
+func (r *Repository) SetURL(url string) { r.url = repositories.RedactURL(url) }
 
 // GetURL get the URL to be parsed.
 func (r *Repository) GetURL() string { return r.url }
 
-// getHost take the provided URL and return the `Host` property; delegated to
-// the `git-urls` package.
-func getHost(url string) (string, error) {
-	// This function will never return an error as each parser's error is dropped
-	// for the next parser, and the final parser is simply net/url's `URL` type.
-	parsedURL, _ := giturls.Parse(url)
-	if parsedURL.Host == "" {
-		return "", fmt.Errorf("could parse url for host: %s", url)
+// parseMatch parse the redacted URL, whose tokens are u, with the provided
+// parser, stripping the relative URL root of the matched host first.  The
+// root is put back on the repo's Pathname.  Providers implementing
+// `repositories.URLParser` are handed the tokens, which are stripped in
+// place, others are handed the stripped URL.  Providers
+// implementing `api.URLBuilder` and `api.WebURLBuilder` format the repo's
+// URLs.
+func parseMatch(
+	parser pkg.ParserManager,
+	match Match,
+	url string,
+	u *giturl.URL,
+) (*api.Repository, error) {
+	unprefixed := stripPrefix(url, match.Host.Prefix)
+
+	var repo *api.Repository
+	var err error
+	if up, ok := parser.(repositories.URLParser); ok {
+		if unprefixed != url {
+			u.Path = u.Path[len(match.Host.Prefix):]
+		}
+		repo, err = up.ParseURL(unprefixed, u)
+	} else {
+		repo, err = parser.Parse(unprefixed)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if unprefixed != url {
+		repo.Pathname = match.Host.Prefix + repo.Pathname
+	}
+	repo.HREF = url
+	repo.MatchRule = match.Rule
+	repo.Prefix = match.Host.Prefix
+	if builder, ok := parser.(api.URLBuilder); ok {
+		repo.SetURLBuilder(builder)
+	}
+	if builder, ok := parser.(api.WebURLBuilder); ok {
+		repo.SetWebURLBuilder(builder)
+	}
+
+	return repo, nil
+}
+
+// withURL set the redacted URL on errors of the Registry, which only sees
+// the URL's host.
+func withURL(err error, url string) error {
+	var uhe *api.UnsupportedHostError
+	if errors.As(err, &uhe) {
+		uhe.URL = url
+	}
+
+	return err
+}
+
+// stripPrefix remove the relative URL root from the start of the URL's path,
+// as in `https://example.com/gitlab/group/project` to
+// `https://example.com/group/project`.  URLs without a scheme, or whose path
+// does not start with the prefix, are returned unchanged.
+func stripPrefix(url string, prefix string) string {
+	if prefix == "" {
+		return url
+	}
+
+	_, rest, ok := strings.Cut(url, "://")
+	if !ok {
+		return url
+	}
+
+	i := strings.Index(rest, "/")
+	if i == -1 {
+		return url
+	}
+
+	authority := len(url) - len(rest) + i
+	path := url[authority:]
+	if path != prefix && !strings.HasPrefix(path, prefix+"/") {
+		return url
+	}
+
+	return url[:authority] + path[len(prefix):]
+}
+
+// getHost take the tokens of the provided URL and return the host name,
+// without any port.  Remote helper URLs, as in
+// `codecommit::us-east-1://profile@repo`, name no host and are dispatched on
+// the helper's name.
+func getHost(u *giturl.URL) string {
+	for _, helper := range remoteHelpers {
+		if u.Helper == helper || u.Scheme == helper {
+			return helper
+		}
 	}
 
-	return parsedURL.Host, nil
+	return u.Host
 }
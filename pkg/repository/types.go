@@ -22,14 +22,73 @@ package repository
 
 import (
 	"log/slog"
+	"sync"
+	"sync/atomic"
 
 	"github.com/retr0h/git-url-parse/internal"
+	"github.com/retr0h/git-url-parse/pkg"
+	"github.com/retr0h/git-url-parse/pkg/api"
 )
 
 // Repository implementation responsible for Repository operations.
 type Repository struct {
 	logger *slog.Logger
 
+	registry *Registry
+
 	parser internal.ParserManager
+	match  Match
 	url    string
 }
+
+// Parser implementation responsible for stateless parsing operations.  A
+// Parser holds no per-URL state and is safe for concurrent use.
+type Parser struct {
+	logger *slog.Logger
+
+	registry   *Registry
+	resolver   pkg.RefResolver
+	strictRefs bool
+}
+
+// Registry implementation responsible for provider lookup operations.  A
+// Registry is safe for concurrent use.
+type Registry struct {
+	mu sync.RWMutex
+
+	providers  []Provider
+	hosts      map[string]Host
+	heuristics bool
+
+	// index the Match resolved for each host, cleared whenever providers
+	// or hosts change
+	index     *sync.Map
+	indexSize atomic.Int64
+}
+
+// Host a self-hosted instance of a registered provider.
+type Host struct {
+	// Name the host name, as in `git.example.com`.
+	Name string
+	// Provider the name of the registered provider which parses the host.
+	Provider string
+	// Prefix the relative URL root the instance is served under, as in
+	// `/gitlab`.  Stripped from the URL's path before the provider parses
+	// it.
+	Prefix string
+}
+
+// Provider a parser registered under a unique name and priority.
+type Provider struct {
+	Name     string
+	Priority int
+	Parser   pkg.ParserManager
+}
+
+// Match a provider resolved for a host, and the rule which selected it.
+type Match struct {
+	Provider Provider
+	Rule     api.MatchRule
+	// Host the declared host, when selected by MatchRuleDeclared.
+	Host Host
+}
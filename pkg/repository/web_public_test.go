@@ -0,0 +1,324 @@
+// Copyright (c) 2024 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package repository_test
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/retr0h/git-url-parse/pkg/api"
+	"github.com/retr0h/git-url-parse/pkg/repository"
+)
+
+type WebPublicTestSuite struct {
+	suite.Suite
+
+	p *repository.Parser
+
+	logger *slog.Logger
+}
+
+func (suite *WebPublicTestSuite) SetupTest() {
+	suite.logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	suite.p = repository.NewParser(suite.logger)
+	err := suite.p.Registry().RegisterHost(repository.Host{
+		Name:     "github.example.com",
+		Provider: "github",
+	})
+	require.NoError(suite.T(), err)
+}
+
+func (suite *WebPublicTestSuite) TestWebURL() {
+	type test struct {
+		input    string
+		view     api.View
+		location api.Location
+		want     string
+		wantErr  bool
+	}
+
+	readme := api.Location{Ref: "main", Path: "README.md"}
+	tests := []test{
+		{
+			input:    "git@github.com:owner/repo.git",
+			view:     api.ViewBlob,
+			location: readme,
+			want:     "https://github.com/owner/repo/blob/main/README.md",
+			wantErr:  false,
+		},
+		{
+			input:    "git@github.com:owner/repo.git",
+			view:     api.ViewRaw,
+			location: readme,
+			want:     "https://raw.githubusercontent.com/owner/repo/main/README.md",
+			wantErr:  false,
+		},
+		{
+			input:    "https://raw.githubusercontent.com/owner/repo/main/README.md",
+			view:     api.ViewBlame,
+			location: readme,
+			want:     "https://github.com/owner/repo/blame/main/README.md",
+			wantErr:  false,
+		},
+		{
+			input:    "https://raw.github.example.com/owner/repo/main/README.md",
+			view:     api.ViewRaw,
+			location: readme,
+			want:     "https://github.example.com/owner/repo/raw/main/README.md",
+			wantErr:  false,
+		},
+		{
+			input:    "git@gitlab.com:group/sub/project.git",
+			view:     api.ViewRaw,
+			location: readme,
+			want:     "https://gitlab.com/group/sub/project/-/raw/main/README.md",
+			wantErr:  false,
+		},
+		{
+			input:    "https://bitbucket.org/owner/repo.git",
+			view:     api.ViewBlob,
+			location: readme,
+			want:     "https://bitbucket.org/owner/repo/src/main/README.md",
+			wantErr:  false,
+		},
+		{
+			input:    "git@ssh.dev.azure.com:v3/org/project/repo",
+			view:     api.ViewBlob,
+			location: readme,
+			want:     "https://dev.azure.com/org/project/_git/repo?path=%2FREADME.md&version=GBmain",
+			wantErr:  false,
+		},
+		// failure cases
+		{
+			input:    "https://example.com/owner/repo.git",
+			view:     api.ViewBlob,
+			location: readme,
+			wantErr:  true,
+		},
+		{
+			input:    "git@github.com:owner/repo.git",
+			view:     api.ViewBlob,
+			location: api.Location{Ref: "main"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		repo, err := suite.p.Parse(tc.input)
+		require.NoError(suite.T(), err)
+
+		got, err := repo.WebURL(tc.view, tc.location)
+
+		if tc.wantErr {
+			assert.Error(suite.T(), err, tc.input)
+		} else {
+			require.NoError(suite.T(), err, tc.input)
+			assert.Equal(suite.T(), tc.want, got, tc.input)
+		}
+	}
+}
+
+func (suite *WebPublicTestSuite) TestWebURLWithHostPrefix() {
+	err := suite.p.Registry().RegisterHost(repository.Host{
+		Name:     "example.com",
+		Provider: "gitlab",
+		Prefix:   "/gitlab",
+	})
+	require.NoError(suite.T(), err)
+
+	repo, err := suite.p.Parse("git@example.com:group/repo.git")
+	require.NoError(suite.T(), err)
+
+	got, err := repo.WebURL(api.ViewTree, api.Location{Ref: "main", Path: "docs"})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "https://example.com/gitlab/group/repo/-/tree/main/docs", got)
+}
+
+func (suite *WebPublicTestSuite) TestWebURLWithIPv6Host() {
+	err := suite.p.Registry().RegisterHost(repository.Host{
+		Name:     "2001:db8::1",
+		Provider: "gitea",
+	})
+	require.NoError(suite.T(), err)
+
+	repo, err := suite.p.Parse("https://[2001:db8::1]:3000/owner/repo.git")
+	require.NoError(suite.T(), err)
+
+	got, err := repo.WebURL(api.ViewTree, api.Location{Ref: "main", Path: "docs"})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "https://[2001:db8::1]:3000/owner/repo/src/branch/main/docs", got)
+
+	again, err := suite.p.Parse(got)
+	require.NoError(suite.T(), err, got)
+	assert.Equal(suite.T(), "docs", again.GetPath())
+}
+
+// TestWebURLRoundTrip proves the web URLs of providers which parse their own
+// web URLs yield the same repo, ref and path.
+func (suite *WebPublicTestSuite) TestWebURLRoundTrip() {
+	inputs := []string{
+		"git@github.com:owner/repo.git",
+		"git@gitlab.com:group/sub/project.git",
+		"git@bitbucket.org:owner/repo.git",
+		"git@codeberg.org:owner/repo.git",
+		"https://go.googlesource.com/go",
+	}
+
+	views := []api.View{
+		api.ViewTree,
+		api.ViewBlob,
+	}
+
+	for _, input := range inputs {
+		want, err := suite.p.Parse(input)
+		require.NoError(suite.T(), err)
+
+		for _, view := range views {
+			url, err := want.WebURL(view, api.Location{Ref: "main", Path: "docs/README.md"})
+			require.NoError(suite.T(), err, input)
+
+			got, err := suite.p.Parse(url)
+			require.NoError(suite.T(), err, url)
+
+			assert.Equal(suite.T(), want.GetProviderName(), got.GetProviderName(), url)
+			assert.Equal(suite.T(), want.GetOwnerName(), got.GetOwnerName(), url)
+			assert.Equal(suite.T(), want.GetRepoName(), got.GetRepoName(), url)
+			assert.Equal(suite.T(), "main", got.GetBranchName(), url)
+			assert.Equal(suite.T(), "docs/README.md", got.GetPath(), url)
+		}
+	}
+}
+
+// TestWebURLEscapedPathRoundTrip proves parsed paths, which are decoded, are
+// escaped once.
+func (suite *WebPublicTestSuite) TestWebURLEscapedPathRoundTrip() {
+	type test struct {
+		input string
+		want  string
+	}
+
+	tests := []test{
+		{
+			input: "https://github.com/o/r/tree/main/dir%20x",
+			want:  "https://github.com/o/r/blob/main/dir%20x",
+		},
+		{
+			input: "https://gitlab.com/group/r/-/blob/main/dir%20x/read%20me.md",
+			want:  "https://gitlab.com/group/r/-/blob/main/dir%20x/read%20me.md",
+		},
+		{
+			input: "https://bitbucket.org/o/r/src/main/dir%20x",
+			want:  "https://bitbucket.org/o/r/src/main/dir%20x",
+		},
+		{
+			input: "https://codeberg.org/o/r/src/branch/main/dir%20x",
+			want:  "https://codeberg.org/o/r/src/branch/main/dir%20x",
+		},
+		{
+			input: "https://git.sr.ht/~o/r/tree/main/item/dir%20x",
+			want:  "https://git.sr.ht/~o/r/tree/main/item/dir%20x",
+		},
+		{
+			input: "https://github.com/o/r/blob/main/100%25.md",
+			want:  "https://github.com/o/r/blob/main/100%25.md",
+		},
+	}
+
+	for _, tc := range tests {
+		repo, err := suite.p.Parse(tc.input)
+		require.NoError(suite.T(), err, tc.input)
+
+		got, err := repo.WebURL(api.ViewBlob, api.Location{
+			Ref:  repo.GetBranchName(),
+			Path: repo.GetPath(),
+		})
+		require.NoError(suite.T(), err, tc.input)
+		assert.Equal(suite.T(), tc.want, got, tc.input)
+
+		again, err := suite.p.Parse(got)
+		require.NoError(suite.T(), err, got)
+		assert.Equal(suite.T(), repo.GetPath(), again.GetPath(), got)
+	}
+}
+
+// TestWebURLRefKindRoundTrip proves tags parsed from URLs which name the kind
+// of ref link back to tags.
+func (suite *WebPublicTestSuite) TestWebURLRefKindRoundTrip() {
+	inputs := []string{
+		"https://codeberg.org/o/r/src/tag/v1/x",
+		"https://codeberg.org/o/r/src/branch/main/x",
+		"https://dev.azure.com/org/project/_git/repo?path=%2Fx&version=GTv1",
+		"https://dev.azure.com/org/project/_git/repo?path=%2Fx&version=GBmain",
+	}
+
+	for _, input := range inputs {
+		repo, err := suite.p.Parse(input)
+		require.NoError(suite.T(), err, input)
+
+		got, err := repo.WebURL(api.ViewBlob, api.Location{
+			Ref:     repo.GetRef().Name,
+			RefKind: repo.GetRef().Kind,
+			Path:    repo.GetPath(),
+		})
+		require.NoError(suite.T(), err, input)
+		assert.Equal(suite.T(), input, got)
+	}
+}
+
+// TestWebURLLineAnchorRoundTrip proves the line anchors emitted by each
+// provider are parsed back into the same lines.
+func (suite *WebPublicTestSuite) TestWebURLLineAnchorRoundTrip() {
+	inputs := []string{
+		"git@github.com:owner/repo.git",
+		"git@gitlab.com:group/sub/project.git",
+		"git@bitbucket.org:owner/repo.git",
+		"git@codeberg.org:owner/repo.git",
+		"https://dev.azure.com/org/project/_git/repo",
+	}
+
+	location := api.Location{Ref: "main", Path: "x.go", LineStart: 10, LineEnd: 20}
+	for _, input := range inputs {
+		repo, err := suite.p.Parse(input)
+		require.NoError(suite.T(), err)
+
+		url, err := repo.WebURL(api.ViewBlob, location)
+		require.NoError(suite.T(), err, input)
+
+		got, err := suite.p.Parse(url)
+		require.NoError(suite.T(), err, url)
+
+		assert.Equal(suite.T(), "x.go", got.GetPath(), url)
+		assert.Equal(suite.T(), 10, got.GetLineStart(), url)
+		assert.Equal(suite.T(), 20, got.GetLineEnd(), url)
+	}
+}
+
+// In order for `go test` to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run.
+func TestWebPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(WebPublicTestSuite))
+}